@@ -3,17 +3,21 @@ package main
 import (
 	"context"
 	_ "employees/docs"
+	"employees/internal/pkg/auth"
 	"employees/internal/pkg/config"
 	"employees/internal/pkg/db"
 	"employees/internal/pkg/employee"
 	employeeHttp "employees/internal/pkg/employee/delivery/http"
 	"employees/internal/pkg/employee/repo"
 	"employees/internal/pkg/employee/usecase"
+	"employees/internal/pkg/employee/worker"
 	"employees/internal/pkg/logger"
+	"employees/internal/pkg/pii"
 	"employees/internal/pkg/server"
 	"employees/migrations"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
+	"log"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -26,21 +30,38 @@ import (
 // @host localhost:8080
 // @schemes http
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT (HS256 или RS256) в виде "Bearer <token>", claims role, company_id и department_id задают права вызывающего
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Статический ключ сервиса
 func main() {
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1])
+		return
+	}
+
 	app := fx.New(
 		// конструкторы
 		fx.Provide(
-			logger.SetupLogger,
+			logger.New,
 			server.NewRouter,
 
 			config.MustLoad,
 
+			auth.New,
+			pii.New,
+
 			db.NewPostgresConn,
 			db.NewPostgresPool,
 
 			employeeHttp.New,
 			fx.Annotate(usecase.New, fx.As(new(employee.Usecase))),
 			fx.Annotate(repo.New, fx.As(new(employee.Repository))),
+			fx.Annotate(db.NewTxManager, fx.As(new(employee.TxManager))),
 		),
 
 		fx.WithLogger(func(logger *slog.Logger) fxevent.Logger {
@@ -50,6 +71,9 @@ func main() {
 		fx.Invoke(
 			server.RunServer,
 			migrations.RunMirgations,
+			worker.RunPurge,
+			worker.RunTransfers,
+			worker.RunEncryption,
 		),
 	)
 
@@ -65,3 +89,32 @@ func main() {
 	<-stop
 	app.Stop(ctx)
 }
+
+// commands are the maintenance jobs run as "main <command>" against the migrated database
+var commands = map[string]any{
+	"encrypt-documents": worker.EncryptDocuments,
+	"rotate-keys":       worker.RotateKeys,
+}
+
+// runCommand runs the command to completion instead of the server
+func runCommand(name string) {
+	command, ok := commands[name]
+	if !ok {
+		log.Fatalf("unknown command %q", name)
+	}
+
+	app := fx.New(
+		fx.Provide(
+			logger.New,
+			config.MustLoad,
+			pii.New,
+			db.NewPostgresPool,
+			fx.Annotate(repo.New, fx.As(new(employee.Repository))),
+		),
+		fx.NopLogger,
+		fx.Invoke(command),
+	)
+	if err := app.Err(); err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+}
@@ -7,8 +7,103 @@ package gen
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveEmployee = `-- name: ArchiveEmployee :execrows
+UPDATE employees
+SET deleted_at=now(),
+    updated_at=now(),
+    version=version + 1
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) ArchiveEmployee(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, archiveEmployee, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const bumpEmployeeVersion = `-- name: BumpEmployeeVersion :exec
+UPDATE employees
+SET updated_at=now(),
+    version=version + 1
+WHERE id = $1
+`
+
+func (q *Queries) BumpEmployeeVersion(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, bumpEmployeeVersion, id)
+	return err
+}
+
+type CopyEmployeesParams struct {
+	Name         string
+	Surname      string
+	Phone        string
+	CompanyID    int32
+	DepartmentID int32
+}
+
+const countCompanyEmployees = `-- name: CountCompanyEmployees :one
+SELECT count(*)
+FROM employees
+WHERE company_id = $1
+`
+
+func (q *Queries) CountCompanyEmployees(ctx context.Context, companyID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countCompanyEmployees, companyID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countDepartmentEmployees = `-- name: CountDepartmentEmployees :one
+SELECT count(*)
+FROM employees
+WHERE department_id = $1
+`
+
+func (q *Queries) CountDepartmentEmployees(ctx context.Context, departmentID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countDepartmentEmployees, departmentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEmployees = `-- name: CountEmployees :one
+SELECT count(*)
+FROM employees e
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.deleted_at IS NULL
+  AND ($1::int IS NULL OR e.company_id = $1)
+  AND ($2::int IS NULL OR e.department_id = $2)
+  AND ($3::text IS NULL OR e.surname LIKE $3 || '%')
+  AND ($4::text IS NULL OR p.type = $4)
+`
+
+type CountEmployeesParams struct {
+	CompanyID     pgtype.Int4
+	DepartmentID  pgtype.Int4
+	SurnamePrefix pgtype.Text
+	PassportType  pgtype.Text
+}
+
+func (q *Queries) CountEmployees(ctx context.Context, arg CountEmployeesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countEmployees,
+		arg.CompanyID,
+		arg.DepartmentID,
+		arg.SurnamePrefix,
+		arg.PassportType,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCompany = `-- name: CreateCompany :one
 INSERT INTO companies (name)
 VALUES ($1) RETURNING id
@@ -22,37 +117,42 @@ func (q *Queries) CreateCompany(ctx context.Context, name string) (int32, error)
 }
 
 const createDepartment = `-- name: CreateDepartment :one
-INSERT INTO departments (name, phone, company_id)
-VALUES ($1, $2, $3) ON CONFLICT (name, company_id) DO NOTHING
+INSERT INTO departments (name, phone, company_id, parent_department_id)
+VALUES ($1, $2, $3, $4) ON CONFLICT (name, company_id) DO NOTHING
 RETURNING id
 `
 
 type CreateDepartmentParams struct {
-	Name      string
-	Phone     string
-	CompanyID int32
+	Name               string
+	Phone              string
+	CompanyID          int32
+	ParentDepartmentID pgtype.Int4
 }
 
 func (q *Queries) CreateDepartment(ctx context.Context, arg CreateDepartmentParams) (int32, error) {
-	row := q.db.QueryRow(ctx, createDepartment, arg.Name, arg.Phone, arg.CompanyID)
+	row := q.db.QueryRow(ctx, createDepartment,
+		arg.Name,
+		arg.Phone,
+		arg.CompanyID,
+		arg.ParentDepartmentID,
+	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
 const createEmployee = `-- name: CreateEmployee :one
-INSERT INTO employees (name, surname, phone, company_id, department_id, passport_type, passport_number)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+INSERT INTO employees (name, surname, phone, company_id, department_id, manager_id)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
 `
 
 type CreateEmployeeParams struct {
-	Name           string
-	Surname        string
-	Phone          string
-	CompanyID      int32
-	DepartmentID   int32
-	PassportType   string
-	PassportNumber string
+	Name         string
+	Surname      string
+	Phone        string
+	CompanyID    int32
+	DepartmentID int32
+	ManagerID    pgtype.Int4
 }
 
 func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams) (int32, error) {
@@ -62,40 +162,248 @@ func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams)
 		arg.Phone,
 		arg.CompanyID,
 		arg.DepartmentID,
-		arg.PassportType,
-		arg.PassportNumber,
+		arg.ManagerID,
 	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
-const deleteEmployee = `-- name: DeleteEmployee :exec
+const deleteCompany = `-- name: DeleteCompany :execrows
 DELETE
-FROM employees
+FROM companies
 WHERE id = $1
 `
 
-func (q *Queries) DeleteEmployee(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteEmployee, id)
-	return err
+func (q *Queries) DeleteCompany(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCompany, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteDepartment = `-- name: DeleteDepartment :execrows
+DELETE
+FROM departments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteDepartment(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDepartment, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const findEmployeesByIdentifiers = `-- name: FindEmployeesByIdentifiers :many
+SELECT e.id,
+       e.phone,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext
+FROM employees e
+         LEFT JOIN identity_documents p ON p.employee_id = e.id
+    AND (p.number_hash = ANY ($1::bytea[]) OR p.number = ANY ($2::text[]))
+WHERE (e.deleted_at IS NULL AND e.phone = ANY ($3::text[]))
+   OR p.id IS NOT NULL
+`
+
+type FindEmployeesByIdentifiersParams struct {
+	PassportHashes  [][]byte
+	PassportNumbers []string
+	Phones          []string
+}
+
+type FindEmployeesByIdentifiersRow struct {
+	ID                 int32
+	Phone              string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+}
+
+func (q *Queries) FindEmployeesByIdentifiers(ctx context.Context, arg FindEmployeesByIdentifiersParams) ([]FindEmployeesByIdentifiersRow, error) {
+	rows, err := q.db.Query(ctx, findEmployeesByIdentifiers, arg.PassportHashes, arg.PassportNumbers, arg.Phones)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindEmployeesByIdentifiersRow
+	for rows.Next() {
+		var i FindEmployeesByIdentifiersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Phone,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompanies = `-- name: GetCompanies :many
+SELECT id, name
+FROM companies
+ORDER BY id asc
+LIMIT $1 OFFSET $2
+`
+
+type GetCompaniesParams struct {
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) GetCompanies(ctx context.Context, arg GetCompaniesParams) ([]Company, error) {
+	rows, err := q.db.Query(ctx, getCompanies, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Company
+	for rows.Next() {
+		var i Company
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompanyByID = `-- name: GetCompanyByID :one
+SELECT id, name
+FROM companies
+WHERE id = $1
+`
+
+func (q *Queries) GetCompanyByID(ctx context.Context, id int32) (Company, error) {
+	row := q.db.QueryRow(ctx, getCompanyByID, id)
+	var i Company
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const getCompanyDepartments = `-- name: GetCompanyDepartments :many
+SELECT id, name, phone, company_id, parent_department_id
+FROM departments
+WHERE company_id = $1
+ORDER BY id asc
+`
+
+type GetCompanyDepartmentsRow struct {
+	ID                 int32
+	Name               string
+	Phone              string
+	CompanyID          int32
+	ParentDepartmentID pgtype.Int4
+}
+
+func (q *Queries) GetCompanyDepartments(ctx context.Context, companyID int32) ([]GetCompanyDepartmentsRow, error) {
+	rows, err := q.db.Query(ctx, getCompanyDepartments, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCompanyDepartmentsRow
+	for rows.Next() {
+		var i GetCompanyDepartmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Phone,
+			&i.CompanyID,
+			&i.ParentDepartmentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompanyForUpdate = `-- name: GetCompanyForUpdate :one
+SELECT id, name
+FROM companies
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetCompanyForUpdate(ctx context.Context, id int32) (Company, error) {
+	row := q.db.QueryRow(ctx, getCompanyForUpdate, id)
+	var i Company
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
 }
 
 const getDepartmentByID = `-- name: GetDepartmentByID :one
-SELECT name, phone
+SELECT id, name, phone, company_id, parent_department_id
 FROM departments
 WHERE id = $1
 `
 
 type GetDepartmentByIDRow struct {
-	Name  string
-	Phone string
+	ID                 int32
+	Name               string
+	Phone              string
+	CompanyID          int32
+	ParentDepartmentID pgtype.Int4
 }
 
 func (q *Queries) GetDepartmentByID(ctx context.Context, id int32) (GetDepartmentByIDRow, error) {
 	row := q.db.QueryRow(ctx, getDepartmentByID, id)
 	var i GetDepartmentByIDRow
-	err := row.Scan(&i.Name, &i.Phone)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Phone,
+		&i.CompanyID,
+		&i.ParentDepartmentID,
+	)
+	return i, err
+}
+
+const getDepartmentForUpdate = `-- name: GetDepartmentForUpdate :one
+SELECT id, name, phone, company_id, parent_department_id
+FROM departments
+WHERE id = $1
+FOR UPDATE
+`
+
+type GetDepartmentForUpdateRow struct {
+	ID                 int32
+	Name               string
+	Phone              string
+	CompanyID          int32
+	ParentDepartmentID pgtype.Int4
+}
+
+func (q *Queries) GetDepartmentForUpdate(ctx context.Context, id int32) (GetDepartmentForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getDepartmentForUpdate, id)
+	var i GetDepartmentForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Phone,
+		&i.CompanyID,
+		&i.ParentDepartmentID,
+	)
 	return i, err
 }
 
@@ -112,28 +420,94 @@ func (q *Queries) GetDepartmentID(ctx context.Context, id int32) (int32, error)
 	return department_id, err
 }
 
+const getDepartmentSubtree = `-- name: GetDepartmentSubtree :many
+WITH RECURSIVE subtree AS (SELECT id, parent_department_id, 0 AS depth, ARRAY [id] AS path
+                           FROM departments
+                           WHERE departments.id = $1
+                           UNION ALL
+                           SELECT d.id, d.parent_department_id, s.depth + 1, s.path || d.id
+                           FROM departments d
+                                    JOIN subtree s ON d.parent_department_id = s.id
+                           WHERE NOT d.id = ANY (s.path))
+SELECT d.id, d.name, d.phone, d.company_id, d.parent_department_id, s.depth::int AS depth
+FROM subtree s
+         JOIN departments d ON d.id = s.id
+ORDER BY s.path
+`
+
+type GetDepartmentSubtreeRow struct {
+	ID                 int32
+	Name               string
+	Phone              string
+	CompanyID          int32
+	ParentDepartmentID pgtype.Int4
+	Depth              int32
+}
+
+func (q *Queries) GetDepartmentSubtree(ctx context.Context, id int32) ([]GetDepartmentSubtreeRow, error) {
+	rows, err := q.db.Query(ctx, getDepartmentSubtree, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDepartmentSubtreeRow
+	for rows.Next() {
+		var i GetDepartmentSubtreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Phone,
+			&i.CompanyID,
+			&i.ParentDepartmentID,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEmployeeByID = `-- name: GetEmployeeByID :one
-SELECT id,
-       name,
-       surname,
-       phone,
-       company_id,
-       passport_type,
-       passport_number,
-       department_id
-FROM employees
-WHERE id = $1
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.department_id,
+       e.version,
+       e.manager_id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.id = $1
+  AND e.deleted_at IS NULL
 `
 
 type GetEmployeeByIDRow struct {
-	ID             int32
-	Name           string
-	Surname        string
-	Phone          string
-	CompanyID      int32
-	PassportType   string
-	PassportNumber string
-	DepartmentID   int32
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	DepartmentID       int32
+	Version            int32
+	ManagerID          pgtype.Int4
+	Name_2             string
+	Phone_2            string
 }
 
 func (q *Queries) GetEmployeeByID(ctx context.Context, id int32) (GetEmployeeByIDRow, error) {
@@ -147,48 +521,148 @@ func (q *Queries) GetEmployeeByID(ctx context.Context, id int32) (GetEmployeeByI
 		&i.CompanyID,
 		&i.PassportType,
 		&i.PassportNumber,
+		&i.PassportKeyID,
+		&i.PassportCiphertext,
 		&i.DepartmentID,
+		&i.Version,
+		&i.ManagerID,
+		&i.Name_2,
+		&i.Phone_2,
 	)
 	return i, err
 }
 
-const getListCompanyDepartmentEmployee = `-- name: GetListCompanyDepartmentEmployee :many
+const getEmployeeForUpdate = `-- name: GetEmployeeForUpdate :one
 SELECT e.id,
        e.name,
        e.surname,
        e.phone,
        e.company_id,
-       e.passport_type,
-       e.passport_number,
-       d.name,
-       d.phone
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.department_id,
+       e.version,
+       e.manager_id
 FROM employees e
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.id = $1
+  AND e.deleted_at IS NULL
+FOR UPDATE OF e
+`
+
+type GetEmployeeForUpdateRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	DepartmentID       int32
+	Version            int32
+	ManagerID          pgtype.Int4
+}
+
+func (q *Queries) GetEmployeeForUpdate(ctx context.Context, id int32) (GetEmployeeForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getEmployeeForUpdate, id)
+	var i GetEmployeeForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Surname,
+		&i.Phone,
+		&i.CompanyID,
+		&i.PassportType,
+		&i.PassportNumber,
+		&i.PassportKeyID,
+		&i.PassportCiphertext,
+		&i.DepartmentID,
+		&i.Version,
+		&i.ManagerID,
+	)
+	return i, err
+}
+
+const getEmployeeScope = `-- name: GetEmployeeScope :one
+SELECT company_id, department_id
+FROM employees
+WHERE id = $1
+`
+
+type GetEmployeeScopeRow struct {
+	CompanyID    int32
+	DepartmentID int32
+}
+
+func (q *Queries) GetEmployeeScope(ctx context.Context, id int32) (GetEmployeeScopeRow, error) {
+	row := q.db.QueryRow(ctx, getEmployeeScope, id)
+	var i GetEmployeeScopeRow
+	err := row.Scan(&i.CompanyID, &i.DepartmentID)
+	return i, err
+}
+
+const getReportingChain = `-- name: GetReportingChain :many
+WITH RECURSIVE chain AS (SELECT id, manager_id, 0 AS depth, ARRAY [id] AS path
+                         FROM employees
+                         WHERE employees.id = $1
+                           AND deleted_at IS NULL
+                         UNION ALL
+                         SELECT e.id, e.manager_id, c.depth + 1, c.path || e.id
+                         FROM employees e
+                                  JOIN chain c ON e.id = c.manager_id
+                         WHERE e.deleted_at IS NULL
+                           AND NOT e.id = ANY (c.path))
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.manager_id,
+       d.id,
+       d.name,
+       d.phone,
+       c.depth::int AS depth
+FROM chain c
+         JOIN employees e ON e.id = c.id
          JOIN departments d ON e.department_id = d.id
-WHERE e.department_id = $1
-ORDER BY e.id asc
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+ORDER BY c.depth
 `
 
-type GetListCompanyDepartmentEmployeeRow struct {
-	ID             int32
-	Name           string
-	Surname        string
-	Phone          string
-	CompanyID      int32
-	PassportType   string
-	PassportNumber string
-	Name_2         string
-	Phone_2        string
+type GetReportingChainRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	ManagerID          pgtype.Int4
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+	Depth              int32
 }
 
-func (q *Queries) GetListCompanyDepartmentEmployee(ctx context.Context, departmentID int32) ([]GetListCompanyDepartmentEmployeeRow, error) {
-	rows, err := q.db.Query(ctx, getListCompanyDepartmentEmployee, departmentID)
+func (q *Queries) GetReportingChain(ctx context.Context, id int32) ([]GetReportingChainRow, error) {
+	rows, err := q.db.Query(ctx, getReportingChain, id)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetListCompanyDepartmentEmployeeRow
+	var items []GetReportingChainRow
 	for rows.Next() {
-		var i GetListCompanyDepartmentEmployeeRow
+		var i GetReportingChainRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -197,8 +671,13 @@ func (q *Queries) GetListCompanyDepartmentEmployee(ctx context.Context, departme
 			&i.CompanyID,
 			&i.PassportType,
 			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.ManagerID,
+			&i.ID_2,
 			&i.Name_2,
 			&i.Phone_2,
+			&i.Depth,
 		); err != nil {
 			return nil, err
 		}
@@ -210,43 +689,60 @@ func (q *Queries) GetListCompanyDepartmentEmployee(ctx context.Context, departme
 	return items, nil
 }
 
-const getListCompanyEmployee = `-- name: GetListCompanyEmployee :many
+const listArchivedEmployees = `-- name: ListArchivedEmployees :many
 SELECT e.id,
        e.name,
        e.surname,
        e.phone,
        e.company_id,
-       e.passport_type,
-       e.passport_number,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.deleted_at,
+       d.id,
        d.name,
        d.phone
 FROM employees e
          JOIN departments d ON e.department_id = d.id
-WHERE e.company_id = $1
-ORDER BY e.id asc
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.deleted_at IS NOT NULL
+  AND ($1::int IS NULL OR e.company_id = $1)
+ORDER BY e.deleted_at DESC, e.id
+LIMIT $2 OFFSET $3
 `
 
-type GetListCompanyEmployeeRow struct {
-	ID             int32
-	Name           string
-	Surname        string
-	Phone          string
-	CompanyID      int32
-	PassportType   string
-	PassportNumber string
-	Name_2         string
-	Phone_2        string
+type ListArchivedEmployeesParams struct {
+	CompanyID  pgtype.Int4
+	PageLimit  int32
+	PageOffset int32
+}
+
+type ListArchivedEmployeesRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	DeletedAt          pgtype.Timestamptz
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
 }
 
-func (q *Queries) GetListCompanyEmployee(ctx context.Context, companyID int32) ([]GetListCompanyEmployeeRow, error) {
-	rows, err := q.db.Query(ctx, getListCompanyEmployee, companyID)
+func (q *Queries) ListArchivedEmployees(ctx context.Context, arg ListArchivedEmployeesParams) ([]ListArchivedEmployeesRow, error) {
+	rows, err := q.db.Query(ctx, listArchivedEmployees, arg.CompanyID, arg.PageLimit, arg.PageOffset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetListCompanyEmployeeRow
+	var items []ListArchivedEmployeesRow
 	for rows.Next() {
-		var i GetListCompanyEmployeeRow
+		var i ListArchivedEmployeesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -255,6 +751,10 @@ func (q *Queries) GetListCompanyEmployee(ctx context.Context, companyID int32) (
 			&i.CompanyID,
 			&i.PassportType,
 			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.DeletedAt,
+			&i.ID_2,
 			&i.Name_2,
 			&i.Phone_2,
 		); err != nil {
@@ -268,40 +768,702 @@ func (q *Queries) GetListCompanyEmployee(ctx context.Context, companyID int32) (
 	return items, nil
 }
 
-const updateEmployee = `-- name: UpdateEmployee :exec
-UPDATE employees
-SET name=$2,
-    surname=$3,
-    phone=$4,
-    company_id=$5,
-    department_id=$6,
-    passport_type=$7,
-    passport_number=$8,
-    updated_at=now()
-WHERE id = $1
-`
-
-type UpdateEmployeeParams struct {
-	ID             int32
-	Name           string
-	Surname        string
-	Phone          string
-	CompanyID      int32
-	DepartmentID   int32
-	PassportType   string
-	PassportNumber string
-}
-
-func (q *Queries) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams) error {
-	_, err := q.db.Exec(ctx, updateEmployee,
-		arg.ID,
-		arg.Name,
+const listDirectReports = `-- name: ListDirectReports :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.manager_id,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.manager_id = $1::int
+  AND e.deleted_at IS NULL
+ORDER BY e.id
+`
+
+type ListDirectReportsRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	ManagerID          pgtype.Int4
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+}
+
+func (q *Queries) ListDirectReports(ctx context.Context, managerID int32) ([]ListDirectReportsRow, error) {
+	rows, err := q.db.Query(ctx, listDirectReports, managerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDirectReportsRow
+	for rows.Next() {
+		var i ListDirectReportsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Surname,
+			&i.Phone,
+			&i.CompanyID,
+			&i.PassportType,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.ManagerID,
+			&i.ID_2,
+			&i.Name_2,
+			&i.Phone_2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByCreatedAt = `-- name: ListEmployeesByCreatedAt :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = $1
+  AND e.deleted_at IS NULL
+  AND ($2::int IS NULL OR e.department_id = $2)
+  AND ($3::text IS NULL OR e.surname LIKE $3 || '%')
+  AND ($4::text IS NULL OR p.type = $4)
+  AND (e.created_at, e.id) > ($5::timestamptz, $6::int)
+ORDER BY e.created_at, e.id
+LIMIT $7
+`
+
+type ListEmployeesByCreatedAtParams struct {
+	CompanyID      int32
+	DepartmentID   pgtype.Int4
+	SurnamePrefix  pgtype.Text
+	PassportType   pgtype.Text
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        int32
+	PageLimit      int32
+}
+
+type ListEmployeesByCreatedAtRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	CreatedAt          pgtype.Timestamptz
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+}
+
+func (q *Queries) ListEmployeesByCreatedAt(ctx context.Context, arg ListEmployeesByCreatedAtParams) ([]ListEmployeesByCreatedAtRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByCreatedAt,
+		arg.CompanyID,
+		arg.DepartmentID,
+		arg.SurnamePrefix,
+		arg.PassportType,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmployeesByCreatedAtRow
+	for rows.Next() {
+		var i ListEmployeesByCreatedAtRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Surname,
+			&i.Phone,
+			&i.CompanyID,
+			&i.PassportType,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.CreatedAt,
+			&i.ID_2,
+			&i.Name_2,
+			&i.Phone_2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByID = `-- name: ListEmployeesByID :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = $1
+  AND e.deleted_at IS NULL
+  AND ($2::int IS NULL OR e.department_id = $2)
+  AND ($3::text IS NULL OR e.surname LIKE $3 || '%')
+  AND ($4::text IS NULL OR p.type = $4)
+  AND e.id > $5::int
+ORDER BY e.id
+LIMIT $6
+`
+
+type ListEmployeesByIDParams struct {
+	CompanyID     int32
+	DepartmentID  pgtype.Int4
+	SurnamePrefix pgtype.Text
+	PassportType  pgtype.Text
+	AfterID       int32
+	PageLimit     int32
+}
+
+type ListEmployeesByIDRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	CreatedAt          pgtype.Timestamptz
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+}
+
+func (q *Queries) ListEmployeesByID(ctx context.Context, arg ListEmployeesByIDParams) ([]ListEmployeesByIDRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByID,
+		arg.CompanyID,
+		arg.DepartmentID,
+		arg.SurnamePrefix,
+		arg.PassportType,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmployeesByIDRow
+	for rows.Next() {
+		var i ListEmployeesByIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Surname,
+			&i.Phone,
+			&i.CompanyID,
+			&i.PassportType,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.CreatedAt,
+			&i.ID_2,
+			&i.Name_2,
+			&i.Phone_2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesByName = `-- name: ListEmployeesByName :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = $1
+  AND e.deleted_at IS NULL
+  AND ($2::int IS NULL OR e.department_id = $2)
+  AND ($3::text IS NULL OR e.surname LIKE $3 || '%')
+  AND ($4::text IS NULL OR p.type = $4)
+  AND (e.name, e.id) > ($5::text, $6::int)
+ORDER BY e.name, e.id
+LIMIT $7
+`
+
+type ListEmployeesByNameParams struct {
+	CompanyID     int32
+	DepartmentID  pgtype.Int4
+	SurnamePrefix pgtype.Text
+	PassportType  pgtype.Text
+	AfterName     string
+	AfterID       int32
+	PageLimit     int32
+}
+
+type ListEmployeesByNameRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	CreatedAt          pgtype.Timestamptz
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+}
+
+func (q *Queries) ListEmployeesByName(ctx context.Context, arg ListEmployeesByNameParams) ([]ListEmployeesByNameRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeesByName,
+		arg.CompanyID,
+		arg.DepartmentID,
+		arg.SurnamePrefix,
+		arg.PassportType,
+		arg.AfterName,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmployeesByNameRow
+	for rows.Next() {
+		var i ListEmployeesByNameRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Surname,
+			&i.Phone,
+			&i.CompanyID,
+			&i.PassportType,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.CreatedAt,
+			&i.ID_2,
+			&i.Name_2,
+			&i.Phone_2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmployeesBySurname = `-- name: ListEmployeesBySurname :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = $1
+  AND e.deleted_at IS NULL
+  AND ($2::int IS NULL OR e.department_id = $2)
+  AND ($3::text IS NULL OR e.surname LIKE $3 || '%')
+  AND ($4::text IS NULL OR p.type = $4)
+  AND (e.surname, e.id) > ($5::text, $6::int)
+ORDER BY e.surname, e.id
+LIMIT $7
+`
+
+type ListEmployeesBySurnameParams struct {
+	CompanyID     int32
+	DepartmentID  pgtype.Int4
+	SurnamePrefix pgtype.Text
+	PassportType  pgtype.Text
+	AfterSurname  string
+	AfterID       int32
+	PageLimit     int32
+}
+
+type ListEmployeesBySurnameRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	CreatedAt          pgtype.Timestamptz
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+}
+
+func (q *Queries) ListEmployeesBySurname(ctx context.Context, arg ListEmployeesBySurnameParams) ([]ListEmployeesBySurnameRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeesBySurname,
+		arg.CompanyID,
+		arg.DepartmentID,
+		arg.SurnamePrefix,
+		arg.PassportType,
+		arg.AfterSurname,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmployeesBySurnameRow
+	for rows.Next() {
+		var i ListEmployeesBySurnameRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Surname,
+			&i.Phone,
+			&i.CompanyID,
+			&i.PassportType,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.CreatedAt,
+			&i.ID_2,
+			&i.Name_2,
+			&i.Phone_2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeEmployees = `-- name: PurgeEmployees :execrows
+DELETE
+FROM employees
+WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeEmployees(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeEmployees, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreEmployee = `-- name: RestoreEmployee :one
+WITH restored AS (
+    UPDATE employees
+        SET deleted_at = NULL,
+            updated_at = now(),
+            version = version + 1
+        WHERE id = $1
+            AND deleted_at IS NOT NULL
+        RETURNING id, name, surname, phone, company_id, department_id, manager_id)
+SELECT r.id,
+       r.name,
+       r.surname,
+       r.phone,
+       r.company_id,
+       r.department_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       r.manager_id
+FROM restored r
+         LEFT JOIN identity_documents p ON p.employee_id = r.id AND p.is_primary
+`
+
+type RestoreEmployeeRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	DepartmentID       int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	ManagerID          pgtype.Int4
+}
+
+func (q *Queries) RestoreEmployee(ctx context.Context, id int32) (RestoreEmployeeRow, error) {
+	row := q.db.QueryRow(ctx, restoreEmployee, id)
+	var i RestoreEmployeeRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Surname,
+		&i.Phone,
+		&i.CompanyID,
+		&i.DepartmentID,
+		&i.PassportType,
+		&i.PassportNumber,
+		&i.PassportKeyID,
+		&i.PassportCiphertext,
+		&i.ManagerID,
+	)
+	return i, err
+}
+
+const searchEmployees = `-- name: SearchEmployees :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       d.id,
+       d.name,
+       d.phone,
+       (ts_rank(to_tsvector('simple', e.name || ' ' || e.surname || ' ' || e.phone),
+                plainto_tsquery('simple', $1::text)) +
+        greatest(similarity(e.name, $1), similarity(e.surname, $1), similarity(e.phone, $1)))::real AS rank
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.deleted_at IS NULL
+  AND ($2::int IS NULL OR e.company_id = $2)
+  AND (to_tsvector('simple', e.name || ' ' || e.surname || ' ' || e.phone) @@ plainto_tsquery('simple', $1)
+    OR e.name ILIKE '%' || $3::text || '%'
+    OR e.surname ILIKE '%' || $3 || '%'
+    OR e.phone LIKE '%' || $3 || '%'
+    OR e.name % $1
+    OR e.surname % $1)
+ORDER BY rank DESC, e.id
+LIMIT $4
+`
+
+type SearchEmployeesParams struct {
+	Query     string
+	CompanyID pgtype.Int4
+	Pattern   string
+	PageLimit int32
+}
+
+type SearchEmployeesRow struct {
+	ID                 int32
+	Name               string
+	Surname            string
+	Phone              string
+	CompanyID          int32
+	PassportType       string
+	PassportNumber     string
+	PassportKeyID      pgtype.Text
+	PassportCiphertext []byte
+	ID_2               int32
+	Name_2             string
+	Phone_2            string
+	Rank               float32
+}
+
+func (q *Queries) SearchEmployees(ctx context.Context, arg SearchEmployeesParams) ([]SearchEmployeesRow, error) {
+	rows, err := q.db.Query(ctx, searchEmployees,
+		arg.Query,
+		arg.CompanyID,
+		arg.Pattern,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchEmployeesRow
+	for rows.Next() {
+		var i SearchEmployeesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Surname,
+			&i.Phone,
+			&i.CompanyID,
+			&i.PassportType,
+			&i.PassportNumber,
+			&i.PassportKeyID,
+			&i.PassportCiphertext,
+			&i.ID_2,
+			&i.Name_2,
+			&i.Phone_2,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCompany = `-- name: UpdateCompany :execrows
+UPDATE companies
+SET name=$2
+WHERE id = $1
+`
+
+type UpdateCompanyParams struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateCompany, arg.ID, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateDepartment = `-- name: UpdateDepartment :execrows
+UPDATE departments
+SET name=$2,
+    phone=$3,
+    parent_department_id=$4
+WHERE id = $1
+`
+
+type UpdateDepartmentParams struct {
+	ID                 int32
+	Name               string
+	Phone              string
+	ParentDepartmentID pgtype.Int4
+}
+
+func (q *Queries) UpdateDepartment(ctx context.Context, arg UpdateDepartmentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateDepartment,
+		arg.ID,
+		arg.Name,
+		arg.Phone,
+		arg.ParentDepartmentID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateEmployee = `-- name: UpdateEmployee :one
+UPDATE employees
+SET name=$2,
+    surname=$3,
+    phone=$4,
+    company_id=$5,
+    department_id=$6,
+    manager_id=$7,
+    updated_at=now(),
+    version=version + 1
+WHERE id = $1
+RETURNING version
+`
+
+type UpdateEmployeeParams struct {
+	ID           int32
+	Name         string
+	Surname      string
+	Phone        string
+	CompanyID    int32
+	DepartmentID int32
+	ManagerID    pgtype.Int4
+}
+
+func (q *Queries) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateEmployee,
+		arg.ID,
+		arg.Name,
 		arg.Surname,
 		arg.Phone,
 		arg.CompanyID,
 		arg.DepartmentID,
-		arg.PassportType,
-		arg.PassportNumber,
+		arg.ManagerID,
 	)
-	return err
+	var version int32
+	err := row.Scan(&version)
+	return version, err
 }
@@ -8,28 +8,82 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditLog struct {
+	ID            int64
+	EntityType    string
+	EntityID      int32
+	Operation     string
+	Actor         string
+	Before        []byte
+	After         []byte
+	ChangedFields []string
+	CreatedAt     pgtype.Timestamptz
+}
+
 type Company struct {
 	ID   int32
 	Name string
 }
 
 type Department struct {
-	ID        int32
-	Name      string
-	Phone     string
-	CompanyID int32
-	CreatedAt pgtype.Timestamptz
+	ID                 int32
+	Name               string
+	Phone              string
+	CompanyID          int32
+	CreatedAt          pgtype.Timestamptz
+	ParentDepartmentID pgtype.Int4
+}
+
+type DepartmentAssignment struct {
+	ID               int64
+	EmployeeID       int32
+	FromDepartmentID pgtype.Int4
+	DepartmentID     int32
+	EffectiveDate    pgtype.Date
+	Status           string
+	Reason           string
+	Actor            string
+	CreatedAt        pgtype.Timestamptz
+	AppliedAt        pgtype.Timestamptz
 }
 
 type Employee struct {
-	ID             int32
-	Name           string
-	Surname        string
-	Phone          string
-	CompanyID      int32
-	DepartmentID   int32
-	PassportType   string
-	PassportNumber string
-	CreatedAt      pgtype.Timestamptz
+	ID           int32
+	Name         string
+	Surname      string
+	Phone        string
+	CompanyID    int32
+	DepartmentID int32
+	CreatedAt    pgtype.Timestamptz
+	UpdatedAt    pgtype.Timestamptz
+	Version      int32
+	DeletedAt    pgtype.Timestamptz
+	ManagerID    pgtype.Int4
+}
+
+type IdentityDocument struct {
+	ID               int32
+	EmployeeID       int32
+	Type             string
+	Number           pgtype.Text
+	Issuer           string
+	IssueDate        pgtype.Date
+	ExpiryDate       pgtype.Date
+	Country          string
+	IsPrimary        bool
+	Archived         bool
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+	NumberHash       []byte
+	NumberKeyID      pgtype.Text
+	NumberCiphertext []byte
+}
+
+type PiiKeyRotation struct {
+	KeyID          string
+	LastDocumentID int32
+	Rotated        int64
+	StartedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
+	FinishedAt     pgtype.Timestamptz
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// DateLayout is the format of calendar dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// department assignment statuses
+const (
+	AssignmentScheduled = "scheduled"
+	AssignmentApplied   = "applied"
+	AssignmentCancelled = "cancelled"
+)
+
+// Transfer moves an employee to another department of the same company.
+// An empty EffectiveDate or today applies it at once, a future date schedules it.
+type Transfer struct {
+	EmployeeID    int32  `json:"-"`
+	DepartmentID  int32  `json:"department_id"`
+	EffectiveDate string `json:"effective_date" example:"2024-05-01"`
+	Reason        string `json:"reason"`
+}
+
+// Assignment is one entry of the employee department timeline
+type Assignment struct {
+	ID                 int64      `json:"id"`
+	EmployeeID         int32      `json:"employee_id"`
+	FromDepartmentID   *int32     `json:"from_department_id,omitempty"`
+	FromDepartmentName string     `json:"from_department_name,omitempty"`
+	DepartmentID       int32      `json:"department_id"`
+	DepartmentName     string     `json:"department_name,omitempty"`
+	EffectiveDate      string     `json:"effective_date" example:"2024-05-01"`
+	Status             string     `json:"status" enums:"scheduled,applied,cancelled"`
+	Reason             string     `json:"reason,omitempty"`
+	Actor              string     `json:"actor"`
+	CreatedAt          time.Time  `json:"created_at"`
+	AppliedAt          *time.Time `json:"applied_at,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// audited entities
+const (
+	AuditEmployee   = "employee"
+	AuditDepartment = "department"
+	AuditCompany    = "company"
+	AuditDocument   = "identity_document"
+)
+
+// audited operations
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+)
+
+type AuditRecord struct {
+	ID            int64           `json:"id"`
+	Operation     string          `json:"operation"`
+	Actor         string          `json:"actor"`
+	Before        json.RawMessage `json:"before" swaggertype:"object"`
+	After         json.RawMessage `json:"after" swaggertype:"object"`
+	ChangedFields []string        `json:"changed_fields"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
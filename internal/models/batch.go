@@ -0,0 +1,87 @@
+package models
+
+import (
+	"employees/internal/pkg/errs"
+	"encoding/json"
+)
+
+// batch modes
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "best_effort"
+)
+
+// batch operation kinds
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// batch operation statuses
+const (
+	BatchOK         = "ok"
+	BatchFailed     = "failed"
+	BatchRolledBack = "rolled_back"
+	BatchSkipped    = "skipped"
+)
+
+type BatchRequest struct {
+	// Mode is atomic (default): all operations or none, or best_effort: failed operations are skipped
+	Mode       string            `json:"mode" enums:"atomic,best_effort"`
+	Operations []*BatchOperation `json:"operations"`
+}
+
+// BatchOperation is one create, update or delete. Data holds a CreateEmployee for create
+// and a JSON Merge Patch for update, Version is the optional expected employee version.
+type BatchOperation struct {
+	Op      string          `json:"op" enums:"create,update,delete"`
+	ID      int32           `json:"id,omitempty"`
+	Version *int32          `json:"version,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+
+	Create *CreateEmployee `json:"-"`
+	Update *UpdateEmployee `json:"-"`
+}
+
+// BatchOperationError is the error body the single employee endpoints would have returned
+type BatchOperationError struct {
+	Status int               `json:"status"`
+	Code   string            `json:"code"`
+	Msg    string            `json:"msg"`
+	Fields []errs.FieldError `json:"fields,omitempty"`
+	Reason string            `json:"reason,omitempty"`
+}
+
+type BatchOperationResult struct {
+	Index   int                  `json:"index"`
+	Op      string               `json:"op"`
+	Status  string               `json:"status"`
+	ID      int32                `json:"id,omitempty"`
+	Version int32                `json:"version,omitempty"`
+	Error   *BatchOperationError `json:"error,omitempty"`
+	Err     error                `json:"-"`
+}
+
+type BatchResult struct {
+	Mode      string                  `json:"mode"`
+	Committed bool                    `json:"committed"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Results   []*BatchOperationResult `json:"results"`
+}
+
+// EmployeeChange is a validated batch operation handed to the repository
+type EmployeeChange struct {
+	Op       string
+	ID       int32
+	Version  *int32
+	Employee *Employee
+	Update   func(employee *Employee) error
+}
+
+type EmployeeChangeResult struct {
+	ID      int32
+	Version int32
+	Err     error
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// IdentityDocument is an identity document of an employee. The primary document
+// is the one returned as the employee passport.
+type IdentityDocument struct {
+	ID         int32  `json:"id"`
+	EmployeeID int32  `json:"employee_id"`
+	Type       string `json:"type"`
+	Number     string `json:"number"`
+	Issuer     string `json:"issuer,omitempty"`
+	IssueDate  string `json:"issue_date,omitempty" example:"2015-04-20"`
+	ExpiryDate string `json:"expiry_date,omitempty" example:"2035-04-20"`
+	Country    string `json:"country,omitempty" example:"RU"`
+	Primary    bool   `json:"primary"`
+}
+
+type CreateIdentityDocument struct {
+	EmployeeID int32  `json:"-"`
+	Type       string `json:"type"`
+	Number     string `json:"number"`
+	Issuer     string `json:"issuer"`
+	IssueDate  string `json:"issue_date" example:"2015-04-20"`
+	ExpiryDate string `json:"expiry_date" example:"2035-04-20"`
+	Country    string `json:"country" example:"RU"`
+	Primary    bool   `json:"primary"`
+}
+
+// UpdateIdentityDocument changes the fields present in the request, an empty date removes it
+type UpdateIdentityDocument struct {
+	ID         int32   `json:"-"`
+	EmployeeID int32   `json:"-"`
+	Type       *string `json:"type"`
+	Number     *string `json:"number"`
+	Issuer     *string `json:"issuer"`
+	IssueDate  *string `json:"issue_date" example:"2015-04-20"`
+	ExpiryDate *string `json:"expiry_date" example:"2035-04-20"`
+	Country    *string `json:"country" example:"RU"`
+	Primary    *bool   `json:"primary"`
+}
+
+// KeyRotation is the progress of resealing the document numbers with the active key,
+// Total counts the documents of the rotation including the ones rotated before a restart
+type KeyRotation struct {
+	KeyID          string
+	LastDocumentID int32
+	Rotated        int64
+	Total          int64
+	StartedAt      time.Time
+	Finished       bool
+}
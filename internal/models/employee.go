@@ -1,15 +1,29 @@
 package models
 
+import (
+	"employees/internal/pkg/pii"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"time"
+)
+
 type Passport struct {
 	Type   string `json:"type"`
 	Number string `json:"number"`
 }
 
+// LogValue keeps the passport number out of the logs
+func (p Passport) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("type", p.Type), slog.String("number", pii.Mask(p.Number)))
+}
+
 type Department struct {
 	ID        int32  `json:"-"`
 	Name      string `json:"name"`
 	Phone     string `json:"phone"`
 	CompanyID int32  `json:"-"`
+	ParentID  *int32 `json:"-"`
 }
 
 type Employee struct {
@@ -20,6 +34,16 @@ type Employee struct {
 	CompanyID  int32      `json:"company_id"`
 	Passport   Passport   `json:"passport"`
 	Department Department `json:"department"`
+	ManagerID  *int32     `json:"manager_id,omitempty"`
+	CreatedAt  time.Time  `json:"-"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+	Version    int32      `json:"-"`
+}
+
+// EmployeeScope is the company and the department an employee belongs to, archived or not
+type EmployeeScope struct {
+	CompanyID    int32
+	DepartmentID int32
 }
 
 type CreateEmployee struct {
@@ -30,19 +54,127 @@ type CreateEmployee struct {
 	CompanyID    int32    `json:"company_id"`
 	Passport     Passport `json:"passport"`
 	DepartmentID int32    `json:"department_id"`
+	ManagerID    *int32   `json:"manager_id"`
+}
+
+type PassportPatch struct {
+	Type   *string `json:"type"`
+	Number *string `json:"number"`
+}
+
+// UpdateEmployee is a JSON Merge Patch (RFC 7396) document: absent fields stay unchanged.
+// Keys explicitly set to null are collected in NullFields, manager_id is the only field that can be removed this way.
+type UpdateEmployee struct {
+	ID           int32          `json:"-"`
+	Name         *string        `json:"name"`
+	Surname      *string        `json:"surname"`
+	Phone        *string        `json:"phone"`
+	CompanyID    *int32         `json:"company_id"`
+	DepartmentID *int32         `json:"department_id"`
+	ManagerID    *int32         `json:"manager_id"`
+	Passport     *PassportPatch `json:"passport"`
+	NullFields   []string       `json:"-"`
+	// Version is the expected employee version taken from If-Match, nil when the update is unconditional
+	Version *int32 `json:"-"`
+}
+
+func (e *UpdateEmployee) UnmarshalJSON(data []byte) error {
+	type plain UpdateEmployee
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.NullFields = nullKeys(raw, "")
+
+	if passport, ok := raw["passport"]; ok && string(passport) != "null" {
+		var rawPassport map[string]json.RawMessage
+		if err := json.Unmarshal(passport, &rawPassport); err != nil {
+			return err
+		}
+		e.NullFields = append(e.NullFields, nullKeys(rawPassport, "passport.")...)
+	}
+	return nil
+}
+
+func nullKeys(raw map[string]json.RawMessage, prefix string) []string {
+	var keys []string
+	for key, value := range raw {
+		if string(value) == "null" {
+			keys = append(keys, prefix+key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 type Company struct {
-	ID   int32  `json:"-"`
+	ID   int32  `json:"id"`
 	Name string `json:"name"`
 }
 
 type CreateDepartment struct {
+	ID        int32  `json:"-"`
 	Name      string `json:"name"`
 	Phone     string `json:"phone"`
 	CompanyID int32  `json:"company_id"`
+	// ParentID on update moves the department, 0 makes it a top-level one
+	ParentID *int32 `json:"parent_department_id"`
+}
+
+type DepartmentInfo struct {
+	ID        int32  `json:"id"`
+	Name      string `json:"name"`
+	Phone     string `json:"phone"`
+	CompanyID int32  `json:"company_id"`
+	ParentID  *int32 `json:"parent_department_id"`
 }
 
 type ResponseID struct {
 	ID int32 `json:"id"`
 }
+
+type Pagination struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+const (
+	SortByID        = "id"
+	SortByName      = "name"
+	SortBySurname   = "surname"
+	SortByCreatedAt = "created_at"
+)
+
+type EmployeeListParams struct {
+	CompanyID     int32
+	DepartmentID  int32
+	SurnamePrefix string
+	PassportType  string
+	Sort          string
+	Limit         int32
+	After         string
+}
+
+// EmployeeCursor is the decoded position after which the next page starts
+type EmployeeCursor struct {
+	ID        int32
+	Text      string
+	CreatedAt time.Time
+}
+
+type EmployeeSearchParams struct {
+	Query     string
+	CompanyID int32
+	Limit     int32
+}
+
+type EmployeeList struct {
+	Employees  []*Employee `json:"employees"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	// Total is counted for the first page only, pages read with a cursor leave it out
+	Total *int64 `json:"total,omitempty"`
+}
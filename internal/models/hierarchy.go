@@ -0,0 +1,7 @@
+package models
+
+// DepartmentTree is a department with its nested child departments
+type DepartmentTree struct {
+	DepartmentInfo
+	Children []*DepartmentTree `json:"children"`
+}
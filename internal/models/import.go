@@ -0,0 +1,44 @@
+package models
+
+import "employees/internal/pkg/errs"
+
+// ImportEmployee is one line of an import file, the department is referenced by name
+type ImportEmployee struct {
+	Line       int      `json:"-"`
+	Name       string   `json:"name"`
+	Surname    string   `json:"surname"`
+	Phone      string   `json:"phone"`
+	Department string   `json:"department"`
+	Passport   Passport `json:"passport"`
+	// ParseError is set when the line could not be read at all
+	ParseError string `json:"-"`
+}
+
+// import row statuses
+const (
+	ImportCreated = "created"
+	ImportValid   = "valid"
+	ImportFailed  = "failed"
+)
+
+type ImportRowResult struct {
+	Line   int               `json:"line"`
+	Status string            `json:"status"`
+	ID     int32             `json:"id,omitempty"`
+	Errors []errs.FieldError `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// EmployeeIdentifiers are the unique fields of an active employee
+type EmployeeIdentifiers struct {
+	ID             int32
+	Phone          string
+	PassportNumber string
+}
@@ -0,0 +1,23 @@
+package models
+
+// OrgChart is a company with its department tree, every employee is listed under its department
+type OrgChart struct {
+	ID          int32            `json:"id"`
+	Name        string           `json:"name"`
+	Departments []*OrgDepartment `json:"departments"`
+}
+
+type OrgDepartment struct {
+	ID          int32            `json:"id"`
+	Name        string           `json:"name"`
+	Phone       string           `json:"phone"`
+	Employees   []*OrgEmployee   `json:"employees"`
+	Departments []*OrgDepartment `json:"departments"`
+}
+
+type OrgEmployee struct {
+	ID      int32  `json:"id"`
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+	Phone   string `json:"phone"`
+}
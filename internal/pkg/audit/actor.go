@@ -0,0 +1,21 @@
+package audit
+
+import "context"
+
+// Anonymous is recorded when a change is made without a known actor
+const Anonymous = "anonymous"
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns who makes the change, Anonymous when nobody is set
+func ActorFromContext(ctx context.Context) string {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	if !ok || actor == "" {
+		return Anonymous
+	}
+	return actor
+}
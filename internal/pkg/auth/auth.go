@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"employees/internal/pkg/errs"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"go.uber.org/fx"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// APIKeyHeader carries the static key of a calling service
+const APIKeyHeader = "X-API-Key"
+
+type Params struct {
+	fx.In
+
+	Config Config
+}
+
+// Authenticator verifies bearer tokens against the configured key set and API keys of other services
+type Authenticator struct {
+	cfg     Config
+	keys    []key
+	apiKeys map[[sha256.Size]byte]APIKeyConfig
+	now     func() time.Time
+}
+
+func New(p Params) (*Authenticator, error) {
+	a := &Authenticator{
+		cfg:     p.Config,
+		apiKeys: make(map[[sha256.Size]byte]APIKeyConfig, len(p.Config.APIKeys)),
+		now:     time.Now,
+	}
+	if !p.Config.Enabled {
+		return a, nil
+	}
+
+	for _, keyConfig := range p.Config.Keys {
+		k, err := loadKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("auth key %q: %w", keyConfig.ID, err)
+		}
+		a.keys = append(a.keys, k)
+	}
+	for _, apiKey := range p.Config.APIKeys {
+		hash, err := hex.DecodeString(apiKey.SHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("api key %q: sha256 must be %d hex bytes", apiKey.Name, sha256.Size)
+		}
+		if err = validateRole(apiKey.Role, apiKey.CompanyID, apiKey.DepartmentID); err != nil {
+			return nil, fmt.Errorf("api key %q: %w", apiKey.Name, err)
+		}
+		a.apiKeys[[sha256.Size]byte(hash)] = apiKey
+	}
+	if len(a.keys) == 0 && len(a.apiKeys) == 0 {
+		return nil, errors.New("auth is enabled without keys")
+	}
+	return a, nil
+}
+
+// Enabled reports whether requests have to be authenticated
+func (a *Authenticator) Enabled() bool {
+	return a.cfg.Enabled
+}
+
+// Authenticate returns the caller of the request, an API key takes precedence over a bearer token.
+// Errors match errs.ErrUnauthenticated.
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+		service, ok := a.apiKeys[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown api key", errs.ErrUnauthenticated)
+		}
+		return &Identity{
+			Subject:      service.Name,
+			Method:       MethodAPIKey,
+			Role:         service.Role,
+			CompanyID:    service.CompanyID,
+			DepartmentID: service.DepartmentID,
+		}, nil
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, fmt.Errorf("%w: no credentials", errs.ErrUnauthenticated)
+	}
+	c, err := a.verifyToken(strings.TrimSpace(token), a.now())
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{
+		Subject:      c.Subject,
+		Method:       MethodJWT,
+		Role:         c.Role,
+		CompanyID:    c.CompanyID,
+		DepartmentID: c.DepartmentID,
+	}, nil
+}
+
+func loadKey(cfg KeyConfig) (key, error) {
+	k := key{id: cfg.ID, algorithm: cfg.Algorithm}
+	switch cfg.Algorithm {
+	case AlgHS256:
+		k.secret = []byte(os.Getenv(cfg.SecretEnv))
+		if len(k.secret) < sha256.Size {
+			return key{}, fmt.Errorf("secret in %s must be at least %d bytes", cfg.SecretEnv, sha256.Size)
+		}
+	case AlgRS256:
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return key{}, err
+		}
+		if k.publicKey, err = parsePublicKey(data); err != nil {
+			return key{}, err
+		}
+	default:
+		return key{}, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+	return k, nil
+}
+
+// parsePublicKey reads an RSA public key from a PKIX or PKCS #1 PEM block
+func parsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return rsaKey, nil
+}
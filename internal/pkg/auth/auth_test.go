@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"employees/internal/pkg/errs"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func signToken(t *testing.T, header, claims map[string]any, sign func(signingInput []byte) []byte) string {
+	t.Helper()
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		assert.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func hs256(secret string) func(signingInput []byte) []byte {
+	return func(signingInput []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(signingInput)
+		return mac.Sum(nil)
+	}
+}
+
+func rs256(t *testing.T, privateKey *rsa.PrivateKey) func(signingInput []byte) []byte {
+	return func(signingInput []byte) []byte {
+		digest := sha256.Sum256(signingInput)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+		assert.NoError(t, err)
+		return signature
+	}
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":        "alice",
+		"iss":        "employees",
+		"aud":        []string{"employees-api", "reports"},
+		"exp":        testNow.Add(time.Hour).Unix(),
+		"role":       RoleHRManager,
+		"company_id": 1,
+	}
+}
+
+func newTestAuthenticator(t *testing.T) (*Authenticator, *rsa.PrivateKey) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "jwt.pem")
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0o600))
+
+	t.Setenv("TEST_JWT_SECRET", testSecret)
+	apiKeyHash := sha256.Sum256([]byte("service-key"))
+
+	a, err := New(Params{Config: Config{
+		Enabled:  true,
+		Issuer:   "employees",
+		Audience: "employees-api",
+		Leeway:   time.Minute,
+		Keys: []KeyConfig{
+			{ID: "hmac", Algorithm: AlgHS256, SecretEnv: "TEST_JWT_SECRET"},
+			{ID: "rsa", Algorithm: AlgRS256, PublicKeyFile: keyFile},
+		},
+		APIKeys: []APIKeyConfig{{Name: "billing", SHA256: hex.EncodeToString(apiKeyHash[:]), Role: RoleViewer, CompanyID: 2}},
+	}})
+	assert.NoError(t, err)
+	a.now = func() time.Time { return testNow }
+	return a, privateKey
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	a, privateKey := newTestAuthenticator(t)
+
+	with := func(change func(claims map[string]any)) map[string]any {
+		claims := validClaims()
+		change(claims)
+		return claims
+	}
+
+	testTable := []struct {
+		name             string
+		authorization    string
+		apiKey           string
+		expectedIdentity *Identity
+		expectedError    error
+	}{
+		{
+			name:             "hs256",
+			authorization:    "Bearer " + signToken(t, map[string]any{"alg": "HS256", "kid": "hmac"}, validClaims(), hs256(testSecret)),
+			expectedIdentity: &Identity{Subject: "alice", Method: MethodJWT, Role: RoleHRManager, CompanyID: 1},
+		},
+		{
+			name:             "rs256 without kid",
+			authorization:    "Bearer " + signToken(t, map[string]any{"alg": "RS256"}, validClaims(), rs256(t, privateKey)),
+			expectedIdentity: &Identity{Subject: "alice", Method: MethodJWT, Role: RoleHRManager, CompanyID: 1},
+		},
+		{
+			name:             "expired within leeway",
+			authorization:    "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { c["exp"] = testNow.Add(-30 * time.Second).Unix() }), hs256(testSecret)),
+			expectedIdentity: &Identity{Subject: "alice", Method: MethodJWT, Role: RoleHRManager, CompanyID: 1},
+		},
+		{
+			name:             "api key",
+			apiKey:           "service-key",
+			expectedIdentity: &Identity{Subject: "billing", Method: MethodAPIKey, Role: RoleViewer, CompanyID: 2},
+		},
+		{
+			name:          "no credentials",
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "unknown api key",
+			apiKey:        "other-key",
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "wrong secret",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, validClaims(), hs256("another secret")),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "kid of another key",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256", "kid": "rsa"}, validClaims(), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "alg none",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "none"}, validClaims(), func([]byte) []byte { return nil }),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "expired",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { c["exp"] = testNow.Add(-time.Hour).Unix() }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "no expiration",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { delete(c, "exp") }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "not valid yet",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { c["nbf"] = testNow.Add(time.Hour).Unix() }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "another issuer",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { c["iss"] = "other" }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "another audience",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { c["aud"] = "reports" }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "no role",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { delete(c, "role") }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "department head without department",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "HS256"}, with(func(c map[string]any) { c["role"] = RoleDepartmentHead }), hs256(testSecret)),
+			expectedError: errs.ErrUnauthenticated,
+		},
+		{
+			name:          "malformed token",
+			authorization: "Bearer abc.def",
+			expectedError: errs.ErrUnauthenticated,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/employees/1", nil)
+			if testCase.authorization != "" {
+				req.Header.Set("Authorization", testCase.authorization)
+			}
+			if testCase.apiKey != "" {
+				req.Header.Set(APIKeyHeader, testCase.apiKey)
+			}
+
+			identity, err := a.Authenticate(req)
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedIdentity, identity)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("SHORT_SECRET", "short")
+
+	_, err := New(Params{Config: Config{Enabled: true}})
+	assert.Error(t, err)
+
+	_, err = New(Params{Config: Config{Enabled: true, Keys: []KeyConfig{{ID: "k", Algorithm: AlgHS256, SecretEnv: "SHORT_SECRET"}}}})
+	assert.Error(t, err)
+
+	_, err = New(Params{Config: Config{Enabled: true, APIKeys: []APIKeyConfig{{Name: "billing", SHA256: "abc", Role: RoleAdmin}}}})
+	assert.Error(t, err)
+
+	apiKeyHash := sha256.Sum256([]byte("service-key"))
+	_, err = New(Params{Config: Config{Enabled: true, APIKeys: []APIKeyConfig{{Name: "billing", SHA256: hex.EncodeToString(apiKeyHash[:]), Role: "owner"}}}})
+	assert.Error(t, err)
+
+	a, err := New(Params{Config: Config{Enabled: false}})
+	assert.NoError(t, err)
+	assert.False(t, a.Enabled())
+}
+
+func TestIdentity_Actor(t *testing.T) {
+	assert.Equal(t, "alice", (&Identity{Subject: "alice", Method: MethodJWT}).Actor())
+	assert.Equal(t, "service:billing", (&Identity{Subject: "billing", Method: MethodAPIKey}).Actor())
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "time"
+
+// signing algorithms of the accepted tokens
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+type Config struct {
+	Enabled bool `yaml:"enabled" env:"AUTH_ENABLED" env-default:"true"`
+	// Issuer and Audience are checked against the iss and aud claims when set
+	Issuer   string         `yaml:"issuer"`
+	Audience string         `yaml:"audience"`
+	Leeway   time.Duration  `yaml:"leeway" env-default:"30s"`
+	Keys     []KeyConfig    `yaml:"keys"`
+	APIKeys  []APIKeyConfig `yaml:"apiKeys"`
+}
+
+// KeyConfig is one key of the JWT key set, secrets stay out of the config file:
+// SecretEnv names the variable holding an HS256 secret, PublicKeyFile is the PEM file of an RS256 public key
+type KeyConfig struct {
+	ID            string `yaml:"id"`
+	Algorithm     string `yaml:"algorithm"`
+	SecretEnv     string `yaml:"secretEnv"`
+	PublicKeyFile string `yaml:"publicKeyFile"`
+}
+
+// APIKeyConfig is the static key of another service, only the hex SHA-256 of the key is configured.
+// The service acts with the role and the scope given here.
+type APIKeyConfig struct {
+	Name         string `yaml:"name"`
+	SHA256       string `yaml:"sha256"`
+	Role         string `yaml:"role"`
+	CompanyID    int32  `yaml:"companyId"`
+	DepartmentID int32  `yaml:"departmentId"`
+}
@@ -0,0 +1,40 @@
+package auth
+
+import "context"
+
+// how the caller was authenticated
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	// Subject is the sub claim of a token or the name of an API key
+	Subject string
+	Method  string
+	// Role decides the allowed actions, CompanyID and DepartmentID bound them, see Authorize
+	Role         string
+	CompanyID    int32
+	DepartmentID int32
+}
+
+// Actor is how the caller is recorded in the audit log, services are told apart from users
+func (i *Identity) Actor() string {
+	if i.Method == MethodAPIKey {
+		return "service:" + i.Subject
+	}
+	return i.Subject
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller, false when the request was not authenticated
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
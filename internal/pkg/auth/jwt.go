@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"employees/internal/pkg/errs"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// key verifies the signatures of one algorithm, tokens pick it by kid
+type key struct {
+	id        string
+	algorithm string
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+func (k *key) verify(signingInput, signature []byte) bool {
+	switch k.algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signingInput)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case AlgRS256:
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(k.publicKey, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return false
+	}
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	// private claims with the role of the caller
+	Role         string `json:"role"`
+	CompanyID    int32  `json:"company_id"`
+	DepartmentID int32  `json:"department_id"`
+}
+
+// audience is the aud claim, a single string or a list of them
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// verifyToken checks the signature of a compact JWS and the registered claims of its payload
+func (a *Authenticator) verifyToken(token string, now time.Time) (*claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", errs.ErrUnauthenticated)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: malformed token header", errs.ErrUnauthenticated)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token signature", errs.ErrUnauthenticated)
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+	if !slices.ContainsFunc(a.keys, func(k key) bool {
+		// the algorithm comes from the key, a token can not downgrade it
+		return k.algorithm == h.Algorithm && (h.KeyID == "" || h.KeyID == k.id) && k.verify(signingInput, signature)
+	}) {
+		return nil, fmt.Errorf("%w: invalid token signature", errs.ErrUnauthenticated)
+	}
+
+	var c claims
+	if err = decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed token claims", errs.ErrUnauthenticated)
+	}
+	if err = a.checkClaims(&c, now); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (a *Authenticator) checkClaims(c *claims, now time.Time) error {
+	switch {
+	case c.Subject == "":
+		return fmt.Errorf("%w: token has no subject", errs.ErrUnauthenticated)
+	case c.ExpiresAt == nil:
+		return fmt.Errorf("%w: token has no expiration", errs.ErrUnauthenticated)
+	case now.After(numericDate(*c.ExpiresAt).Add(a.cfg.Leeway)):
+		return fmt.Errorf("%w: token expired", errs.ErrUnauthenticated)
+	case c.NotBefore != nil && now.Add(a.cfg.Leeway).Before(numericDate(*c.NotBefore)):
+		return fmt.Errorf("%w: token is not valid yet", errs.ErrUnauthenticated)
+	case a.cfg.Issuer != "" && c.Issuer != a.cfg.Issuer:
+		return fmt.Errorf("%w: unexpected token issuer %q", errs.ErrUnauthenticated, c.Issuer)
+	case a.cfg.Audience != "" && !slices.Contains(c.Audience, a.cfg.Audience):
+		return fmt.Errorf("%w: token is issued for another audience", errs.ErrUnauthenticated)
+	}
+	if err := validateRole(c.Role, c.CompanyID, c.DepartmentID); err != nil {
+		return fmt.Errorf("%w: %w", errs.ErrUnauthenticated, err)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JWT NumericDate, seconds since the epoch that may have a fraction
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
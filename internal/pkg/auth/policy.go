@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"employees/internal/pkg/errs"
+	"fmt"
+	"slices"
+)
+
+// roles of the callers, every role but admin is bound to the company of the caller
+const (
+	RoleAdmin          = "admin"
+	RoleHRManager      = "hr_manager"
+	RoleDepartmentHead = "department_head"
+	RoleViewer         = "viewer"
+)
+
+var roles = []string{RoleAdmin, RoleHRManager, RoleDepartmentHead, RoleViewer}
+
+type Action string
+
+const (
+	// ActionReadCompany reads a company and its department structure
+	ActionReadCompany Action = "company:read"
+	// ActionManageCompany renames a company and changes its departments
+	ActionManageCompany Action = "company:manage"
+	// ActionReadEmployees reads employees with their documents, history and transfers
+	ActionReadEmployees Action = "employees:read"
+	// ActionWriteEmployees creates, changes, transfers, archives and restores employees
+	ActionWriteEmployees Action = "employees:write"
+	// ActionReadPII reads document numbers unmasked, without it they are returned as ****1234
+	ActionReadPII Action = "pii:read"
+	// ActionAdminister covers operations spanning every company: creating and deleting companies,
+	// listing them and maintenance jobs
+	ActionAdminister Action = "admin"
+)
+
+// Resource is what an action applies to. A zero CompanyID means every company,
+// a zero DepartmentID the whole company.
+type Resource struct {
+	CompanyID    int32
+	DepartmentID int32
+}
+
+// scope is how far a grant of a role reaches
+type scope int
+
+const (
+	scopeCompany scope = iota + 1
+	scopeDepartment
+)
+
+// policy lists the grants of the roles bound to a company, admin is allowed everything
+var policy = map[string]map[Action]scope{
+	RoleHRManager: {
+		ActionReadCompany:    scopeCompany,
+		ActionManageCompany:  scopeCompany,
+		ActionReadEmployees:  scopeCompany,
+		ActionWriteEmployees: scopeCompany,
+		ActionReadPII:        scopeCompany,
+	},
+	RoleDepartmentHead: {
+		ActionReadCompany:    scopeCompany,
+		ActionReadEmployees:  scopeDepartment,
+		ActionWriteEmployees: scopeDepartment,
+	},
+	RoleViewer: {
+		ActionReadCompany:   scopeCompany,
+		ActionReadEmployees: scopeCompany,
+	},
+}
+
+// Authorize checks that the caller of ctx may apply the action to the resource, the error matches
+// errs.ErrForbidden and tells the reason. Calls without an identity come from background workers
+// or from a server running with authentication disabled and are allowed.
+func Authorize(ctx context.Context, action Action, resource Resource) error {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return identity.Can(action, resource)
+}
+
+// Restricted reports whether the caller of ctx is bound to a company, so that the company
+// of a resource has to be looked up before Authorize is called
+func Restricted(ctx context.Context) bool {
+	identity, ok := IdentityFromContext(ctx)
+	return ok && identity.Role != RoleAdmin
+}
+
+// Can checks a single action of the identity, see Authorize
+func (i *Identity) Can(action Action, resource Resource) error {
+	if i.Role == RoleAdmin {
+		return nil
+	}
+
+	grant, ok := policy[i.Role][action]
+	switch {
+	case !ok:
+		return forbidden("role %s does not allow %s", i.Role, action)
+	case resource.CompanyID == 0:
+		return forbidden("%s across all companies requires the %s role", action, RoleAdmin)
+	case resource.CompanyID != i.CompanyID:
+		return forbidden("company %d is outside the scope of the caller", resource.CompanyID)
+	case grant == scopeDepartment && resource.DepartmentID == 0:
+		return forbidden("role %s allows %s only within department %d", i.Role, action, i.DepartmentID)
+	case grant == scopeDepartment && resource.DepartmentID != i.DepartmentID:
+		return forbidden("department %d is outside the scope of the caller", resource.DepartmentID)
+	}
+	return nil
+}
+
+// validateRole checks that the role is known and carries the scope it is bound to
+func validateRole(role string, companyID, departmentID int32) error {
+	switch {
+	case !slices.Contains(roles, role):
+		return fmt.Errorf("unknown role %q", role)
+	case role != RoleAdmin && companyID == 0:
+		return fmt.Errorf("role %s requires a company", role)
+	case role == RoleDepartmentHead && departmentID == 0:
+		return fmt.Errorf("role %s requires a department", role)
+	}
+	return nil
+}
+
+func forbidden(format string, args ...any) error {
+	return &errs.ForbiddenError{Reason: fmt.Sprintf(format, args...)}
+}
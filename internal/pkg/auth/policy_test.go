@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"employees/internal/pkg/errs"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentity_Can(t *testing.T) {
+	admin := &Identity{Role: RoleAdmin}
+	hrManager := &Identity{Role: RoleHRManager, CompanyID: 1}
+	departmentHead := &Identity{Role: RoleDepartmentHead, CompanyID: 1, DepartmentID: 10}
+	viewer := &Identity{Role: RoleViewer, CompanyID: 1}
+
+	testTable := []struct {
+		name          string
+		identity      *Identity
+		action        Action
+		resource      Resource
+		expectedError error
+	}{
+		{name: "admin everywhere", identity: admin, action: ActionAdminister},
+		{name: "admin in any company", identity: admin, action: ActionWriteEmployees, resource: Resource{CompanyID: 5}},
+		{name: "hr manager writes in the company", identity: hrManager, action: ActionWriteEmployees, resource: Resource{CompanyID: 1, DepartmentID: 11}},
+		{name: "hr manager manages the company", identity: hrManager, action: ActionManageCompany, resource: Resource{CompanyID: 1}},
+		{
+			name: "hr manager in another company", identity: hrManager, action: ActionReadEmployees,
+			resource: Resource{CompanyID: 2}, expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "hr manager across companies", identity: hrManager, action: ActionReadEmployees,
+			expectedError: errs.ErrForbidden,
+		},
+		{name: "hr manager creates companies", identity: hrManager, action: ActionAdminister, expectedError: errs.ErrForbidden},
+		{name: "department head in the department", identity: departmentHead, action: ActionWriteEmployees, resource: Resource{CompanyID: 1, DepartmentID: 10}},
+		{name: "department head reads the company structure", identity: departmentHead, action: ActionReadCompany, resource: Resource{CompanyID: 1}},
+		{
+			name: "department head in another department", identity: departmentHead, action: ActionReadEmployees,
+			resource: Resource{CompanyID: 1, DepartmentID: 11}, expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "department head on the whole company", identity: departmentHead, action: ActionReadEmployees,
+			resource: Resource{CompanyID: 1}, expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "department head manages departments", identity: departmentHead, action: ActionManageCompany,
+			resource: Resource{CompanyID: 1}, expectedError: errs.ErrForbidden,
+		},
+		{name: "hr manager reads documents numbers", identity: hrManager, action: ActionReadPII, resource: Resource{CompanyID: 1, DepartmentID: 11}},
+		{
+			name: "department head reads documents numbers", identity: departmentHead, action: ActionReadPII,
+			resource: Resource{CompanyID: 1, DepartmentID: 10}, expectedError: errs.ErrForbidden,
+		},
+		{name: "viewer reads the company", identity: viewer, action: ActionReadEmployees, resource: Resource{CompanyID: 1, DepartmentID: 11}},
+		{
+			name: "viewer writes", identity: viewer, action: ActionWriteEmployees,
+			resource: Resource{CompanyID: 1, DepartmentID: 11}, expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "unknown role", identity: &Identity{Role: "owner", CompanyID: 1}, action: ActionReadCompany,
+			resource: Resource{CompanyID: 1}, expectedError: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.identity.Can(testCase.action, testCase.resource)
+			assert.ErrorIs(t, err, testCase.expectedError)
+
+			var forbiddenErr *errs.ForbiddenError
+			if testCase.expectedError != nil && assert.True(t, errors.As(err, &forbiddenErr)) {
+				assert.NotEmpty(t, forbiddenErr.Reason)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, Authorize(ctx, ActionAdminister, Resource{}))
+	assert.False(t, Restricted(ctx))
+
+	ctx = WithIdentity(ctx, &Identity{Subject: "alice", Method: MethodJWT, Role: RoleViewer, CompanyID: 1})
+	assert.ErrorIs(t, Authorize(ctx, ActionAdminister, Resource{}), errs.ErrForbidden)
+	assert.NoError(t, Authorize(ctx, ActionReadCompany, Resource{CompanyID: 1}))
+	assert.True(t, Restricted(ctx))
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"employees/internal/pkg/auth"
 	"employees/internal/pkg/db"
+	handlerEmployee "employees/internal/pkg/employee/delivery/http"
+	"employees/internal/pkg/employee/worker"
+	"employees/internal/pkg/logger"
+	"employees/internal/pkg/pii"
 	"employees/internal/pkg/server"
 	"github.com/ilyakaznacheev/cleanenv"
 	_ "github.com/joho/godotenv/autoload"
@@ -13,15 +18,29 @@ import (
 type Config struct {
 	ConfigPath string `env:"CONFIG_PATH" env-default:"config/config.yaml"`
 
-	HTTPServer server.Config `yaml:"httpServer"`
-	DB         db.Config     `yaml:"db"`
+	HTTPServer server.Config           `yaml:"httpServer"`
+	Auth       auth.Config             `yaml:"auth"`
+	DB         db.Config               `yaml:"db"`
+	Logger     logger.Config           `yaml:"logger"`
+	Employees  handlerEmployee.Config  `yaml:"employees"`
+	Purge      worker.PurgeConfig      `yaml:"purge"`
+	Transfers  worker.TransferConfig   `yaml:"transfers"`
+	PII        pii.Config              `yaml:"pii"`
+	Encryption worker.EncryptionConfig `yaml:"encryption"`
 }
 
 type Out struct {
 	fx.Out
 
 	HTTPServer server.Config
+	Auth       auth.Config
 	DB         db.Config
+	Logger     logger.Config
+	Employees  handlerEmployee.Config
+	Purge      worker.PurgeConfig
+	Transfers  worker.TransferConfig
+	PII        pii.Config
+	Encryption worker.EncryptionConfig
 }
 
 func MustLoad() Out {
@@ -44,6 +63,13 @@ func MustLoad() Out {
 
 	return Out{
 		HTTPServer: cfg.HTTPServer,
+		Auth:       cfg.Auth,
 		DB:         cfg.DB,
+		Logger:     cfg.Logger,
+		Employees:  cfg.Employees,
+		Purge:      cfg.Purge,
+		Transfers:  cfg.Transfers,
+		PII:        cfg.PII,
+		Encryption: cfg.Encryption,
 	}
 }
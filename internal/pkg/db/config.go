@@ -9,4 +9,5 @@ type Config struct {
 	Host           string        `env:"POSTGRES_HOST"`
 	Port           uint16        `env:"POSTGRES_PORT"`
 	ConnectTimeout time.Duration `yaml:"connectTimeout" env-default:"5m"`
+	Tx             TxConfig      `yaml:"tx"`
 }
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+	"log/slog"
+	"time"
+)
+
+// a transaction failing with these codes conflicted with a concurrent one and may succeed on retry.
+// Under read committed the row locks taken with FOR UPDATE end in deadlocks rather than serialization failures.
+const (
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+type TxConfig struct {
+	// Isolation is one of read committed, repeatable read, serializable
+	Isolation  string        `yaml:"isolation" env:"POSTGRES_TX_ISOLATION" env-default:"read committed"`
+	MaxRetries int           `yaml:"maxRetries" env-default:"3"`
+	RetryDelay time.Duration `yaml:"retryDelay" env-default:"20ms"`
+}
+
+var isolationLevels = map[string]pgx.TxIsoLevel{
+	"read committed":  pgx.ReadCommitted,
+	"repeatable read": pgx.RepeatableRead,
+	"serializable":    pgx.Serializable,
+}
+
+type txKey struct{}
+
+// TxFromContext returns the transaction started by TxManager.Do
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+type TxParams struct {
+	fx.In
+
+	Pool   *pgxpool.Pool
+	Cfg    Config
+	Logger *slog.Logger
+}
+
+type TxManager struct {
+	pool    *pgxpool.Pool
+	options pgx.TxOptions
+	cfg     TxConfig
+	log     *slog.Logger
+}
+
+func NewTxManager(p TxParams) (*TxManager, error) {
+	isolation, ok := isolationLevels[p.Cfg.Tx.Isolation]
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction isolation level %q", p.Cfg.Tx.Isolation)
+	}
+
+	return &TxManager{
+		pool:    p.Pool,
+		options: pgx.TxOptions{IsoLevel: isolation},
+		cfg:     p.Cfg.Tx,
+		log:     p.Logger,
+	}, nil
+}
+
+// Do runs fn in one transaction carried by the context passed to fn, repository calls made
+// with that context share it. A call inside a running transaction joins it. Serialization
+// failures and deadlocks restart fn from the beginning, so fn must not keep state between attempts.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := m.run(ctx, fn)
+		if !isRetryable(err) || attempt > m.cfg.MaxRetries {
+			return err
+		}
+
+		m.log.WarnContext(ctx, "retry transaction", "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * m.cfg.RetryDelay):
+		}
+	}
+}
+
+func (m *TxManager) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := m.pool.BeginTx(ctx, m.options)
+	if err != nil {
+		m.log.ErrorContext(ctx, "begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		m.log.ErrorContext(ctx, "commit transaction", "error", err)
+		return err
+	}
+	return nil
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == codeSerializationFailure || pgErr.Code == codeDeadlockDetected
+}
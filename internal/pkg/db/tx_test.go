@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"employees/internal/pkg/logger"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeTx struct {
+	pgx.Tx
+}
+
+func TestNewTxManager(t *testing.T) {
+	manager, err := NewTxManager(TxParams{Cfg: Config{Tx: TxConfig{Isolation: "serializable"}}, Logger: logger.SetupLogger()})
+	assert.NoError(t, err)
+	assert.Equal(t, pgx.Serializable, manager.options.IsoLevel)
+
+	_, err = NewTxManager(TxParams{Cfg: Config{Tx: TxConfig{Isolation: "snapshot"}}, Logger: logger.SetupLogger()})
+	assert.Error(t, err)
+}
+
+func TestTxManager_DoJoinsRunningTransaction(t *testing.T) {
+	manager := &TxManager{log: logger.SetupLogger()}
+	outer := &fakeTx{}
+	ctx := context.WithValue(context.Background(), txKey{}, pgx.Tx(outer))
+
+	calls := 0
+	err := manager.Do(ctx, func(ctx context.Context) error {
+		calls++
+		tx, ok := TxFromContext(ctx)
+		assert.True(t, ok)
+		assert.Same(t, outer, tx)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTxFromContext(t *testing.T) {
+	_, ok := TxFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestIsRetryable(t *testing.T) {
+	testTable := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "serialization failure", err: fmt.Errorf("update: %w", &pgconn.PgError{Code: "40001"}), retryable: true},
+		{name: "deadlock", err: fmt.Errorf("lock employee: %w", &pgconn.PgError{Code: "40P01"}), retryable: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "lock timeout", err: &pgconn.PgError{Code: "55P03"}},
+		{name: "not a postgres error", err: errors.New("connection reset")},
+		{name: "nil", err: nil},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.retryable, isRetryable(testCase.err))
+		})
+	}
+}
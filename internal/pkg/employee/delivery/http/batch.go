@@ -0,0 +1,46 @@
+package http
+
+import (
+	"employees/internal/models"
+	"employees/internal/pkg/utils"
+	"encoding/json"
+	"fmt"
+)
+
+// decodeBatchData decodes the data of every operation into the request of the matching single endpoint
+func decodeBatchData(request *models.BatchRequest) error {
+	for i, operation := range request.Operations {
+		if operation == nil || len(operation.Data) == 0 || string(operation.Data) == "null" {
+			continue
+		}
+
+		var err error
+		switch operation.Op {
+		case models.BatchCreate:
+			err = json.Unmarshal(operation.Data, &operation.Create)
+		case models.BatchUpdate:
+			err = json.Unmarshal(operation.Data, &operation.Update)
+		}
+		if err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// setBatchErrors fills the error body of failed operations
+func setBatchErrors(result *models.BatchResult) {
+	for _, operationResult := range result.Results {
+		if operationResult.Err == nil {
+			continue
+		}
+		status, resp := utils.ErrorStatus(operationResult.Err)
+		operationResult.Error = &models.BatchOperationError{
+			Status: status,
+			Code:   resp.Code,
+			Msg:    resp.Msg,
+			Fields: resp.Fields,
+			Reason: resp.Reason,
+		}
+	}
+}
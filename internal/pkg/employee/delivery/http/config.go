@@ -0,0 +1,6 @@
+package http
+
+type Config struct {
+	// RequireIfMatch rejects PATCH and DELETE on employees without If-Match with 428
+	RequireIfMatch bool `yaml:"requireIfMatch" env:"EMPLOYEES_REQUIRE_IF_MATCH" env-default:"false"`
+}
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"employees/internal/models"
+	"employees/internal/pkg/utils"
+	"employees/internal/pkg/utils/messages"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+// GetEmployeeDocuments godoc
+// @Summary      Получить документы сотрудника
+// @Description  Вывести удостоверяющие документы сотрудника, основной документ первым.
+// @Description  Основной документ возвращается в поле passport сотрудника
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Success      200  {object} []models.IdentityDocument
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/documents [get]
+func (h *Handler) GetEmployeeDocuments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	documents, err := h.uc.GetEmployeeDocuments(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get employee documents", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got employee documents", "id", id, "count", len(documents))
+	utils.Send200(w, documents)
+}
+
+// CreateEmployeeDocument godoc
+// @Summary      Добавить документ сотрудника
+// @Description  Добавить удостоверяющий документ. Пара тип и номер уникальна среди всех документов.
+// @Description  primary = true делает документ основным вместо прежнего, первый документ сотрудника всегда основной
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Param        request body models.CreateIdentityDocument true "document data"
+// @Success      201  {object} models.ResponseID
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/documents [post]
+func (h *Handler) CreateEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	var document *models.CreateIdentityDocument
+	if err = utils.ReadRequestData(r, &document); err != nil || document == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	document.EmployeeID = int32(id)
+	documentID, err := h.uc.CreateEmployeeDocument(r.Context(), document)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "create employee document", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "created employee document", "id", id, "document_id", documentID)
+	utils.Send201(w, models.ResponseID{ID: documentID})
+}
+
+// GetEmployeeDocument godoc
+// @Summary      Получить документ сотрудника
+// @Description  Получить удостоверяющий документ сотрудника по id
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Param        documentId path string true "document id"
+// @Success      200  {object} models.IdentityDocument
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/documents/{documentId} [get]
+func (h *Handler) GetEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	id, documentID, err := documentPath(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse document path", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	document, err := h.uc.GetEmployeeDocument(r.Context(), id, documentID)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get employee document", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got employee document", "id", id, "document_id", documentID)
+	utils.Send200(w, document)
+}
+
+// UpdateEmployeeDocument godoc
+// @Summary      Изменить документ сотрудника
+// @Description  Изменить переданные поля документа, пустая дата удаляет её.
+// @Description  primary = true делает документ основным, снять признак можно только назначив основным другой документ
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Param        documentId path string true "document id"
+// @Param        request body models.UpdateIdentityDocument true "document data"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/documents/{documentId} [patch]
+func (h *Handler) UpdateEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	id, documentID, err := documentPath(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse document path", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	var document *models.UpdateIdentityDocument
+	if err = utils.ReadRequestData(r, &document); err != nil || document == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	document.ID = documentID
+	document.EmployeeID = id
+	if err = h.uc.EditEmployeeDocument(r.Context(), document); err != nil {
+		h.log.ErrorContext(r.Context(), "edit employee document", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "updated employee document", "id", id, "document_id", documentID)
+	utils.Send200(w, utils.MessageResponse{Msg: "document updated"})
+}
+
+// DeleteEmployeeDocument godoc
+// @Summary      Удалить документ сотрудника
+// @Description  Удалить удостоверяющий документ. Основной документ удалить нельзя, сначала нужно назначить основным другой
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Param        documentId path string true "document id"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/documents/{documentId} [delete]
+func (h *Handler) DeleteEmployeeDocument(w http.ResponseWriter, r *http.Request) {
+	id, documentID, err := documentPath(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse document path", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	if err = h.uc.DeleteEmployeeDocument(r.Context(), id, documentID); err != nil {
+		h.log.ErrorContext(r.Context(), "delete employee document", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted employee document", "id", id, "document_id", documentID)
+	utils.Send200(w, utils.MessageResponse{Msg: "document deleted"})
+}
+
+// documentPath returns the employee and the document id of a document route
+func documentPath(r *http.Request) (int32, int32, error) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return 0, 0, err
+	}
+	documentID, err := strconv.Atoi(vars["documentId"])
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(id), int32(documentID), nil
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"employees/internal/models"
+	"employees/internal/pkg/export"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var errUnknownColumn = errors.New("unknown column")
+
+type exportColumn struct {
+	name  string
+	value func(employee *models.Employee) any
+}
+
+// exportColumns lists every exportable column in the default order
+var exportColumns = []exportColumn{
+	{"id", func(e *models.Employee) any { return e.ID }},
+	{"name", func(e *models.Employee) any { return e.Name }},
+	{"surname", func(e *models.Employee) any { return e.Surname }},
+	{"phone", func(e *models.Employee) any { return e.Phone }},
+	{"company_id", func(e *models.Employee) any { return e.CompanyID }},
+	{"department_id", func(e *models.Employee) any { return e.Department.ID }},
+	{"department_name", func(e *models.Employee) any { return e.Department.Name }},
+	{"department_phone", func(e *models.Employee) any { return e.Department.Phone }},
+	{"passport_type", func(e *models.Employee) any { return e.Passport.Type }},
+	{"passport_number", func(e *models.Employee) any { return e.Passport.Number }},
+	{"created_at", func(e *models.Employee) any { return e.CreatedAt }},
+}
+
+// parseExportColumns reads a comma separated column list, an empty list selects all columns
+func parseExportColumns(list string) ([]exportColumn, error) {
+	if strings.TrimSpace(list) == "" {
+		return exportColumns, nil
+	}
+
+	var columns []exportColumn
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+		column, ok := findExportColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownColumn, name)
+		}
+		seen[name] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func findExportColumn(name string) (exportColumn, bool) {
+	for _, column := range exportColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return exportColumn{}, false
+}
+
+// employeeExporter opens the export file on the first row, so errors returned before
+// streaming starts can still be sent as a regular error response
+type employeeExporter struct {
+	w        http.ResponseWriter
+	format   string
+	filename string
+	columns  []exportColumn
+	writer   export.Writer
+	values   []any
+	rows     int
+}
+
+func (e *employeeExporter) start() error {
+	e.w.Header().Set("Content-Type", export.ContentType(e.format))
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.filename+"."+e.format))
+	e.w.WriteHeader(http.StatusOK)
+
+	names := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		names[i] = column.name
+	}
+	writer, err := export.NewWriter(e.format, e.w, names)
+	if err != nil {
+		return err
+	}
+	e.writer = writer
+	e.values = make([]any, len(e.columns))
+	return nil
+}
+
+func (e *employeeExporter) write(employee *models.Employee) error {
+	if e.writer == nil {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	for i, column := range e.columns {
+		e.values[i] = column.value(employee)
+	}
+	e.rows++
+	return e.writer.WriteRow(e.values)
+}
+
+// close finishes the file, writing just the header when there were no rows
+func (e *employeeExporter) close() error {
+	if e.writer == nil {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	return e.writer.Close()
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseExportColumns(t *testing.T) {
+	columns, err := parseExportColumns("")
+	assert.NoError(t, err)
+	assert.Len(t, columns, len(exportColumns))
+
+	columns, err = parseExportColumns(" Surname, phone,surname ")
+	assert.NoError(t, err)
+	assert.Len(t, columns, 2)
+	assert.Equal(t, "surname", columns[0].name)
+	assert.Equal(t, "phone", columns[1].name)
+
+	_, err = parseExportColumns("name,salary")
+	assert.ErrorIs(t, err, errUnknownColumn)
+}
@@ -1,13 +1,20 @@
 package http
 
 import (
+	"bytes"
 	"employees/internal/models"
 	"employees/internal/pkg/employee"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/export"
+	"employees/internal/pkg/orgchart"
 	"employees/internal/pkg/utils"
 	"employees/internal/pkg/utils/messages"
+	"fmt"
 	"github.com/gorilla/mux"
 	"go.uber.org/fx"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
 )
@@ -15,17 +22,20 @@ import (
 type Params struct {
 	fx.In
 
+	Config Config
 	Uc     employee.Usecase
 	Logger *slog.Logger
 }
 
 type Handler struct {
+	cfg Config
 	uc  employee.Usecase
 	log *slog.Logger
 }
 
 func New(p Params) *Handler {
 	return &Handler{
+		cfg: p.Config,
 		uc:  p.Uc,
 		log: p.Logger,
 	}
@@ -39,49 +49,76 @@ func New(p Params) *Handler {
 // @Produce      json
 // @Param        request body models.CreateEmployee true "employee data"
 // @Success      201  {object} models.ResponseID
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /employees [post]
 func (h *Handler) CreateEmployee(w http.ResponseWriter, r *http.Request) {
 	var employeeData *models.CreateEmployee
 
-	if err := utils.ReadRequestData(r, &employeeData); err != nil {
-		h.log.Error("read request data", "error", err.Error())
+	if err := utils.ReadRequestData(r, &employeeData); err != nil || employeeData == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
 
-	h.log.Debug("create employee", "data", employeeData)
+	// the request carries the passport, only the placement of the employee is logged
+	h.log.DebugContext(r.Context(), "create employee", "company_id", employeeData.CompanyID, "department_id", employeeData.DepartmentID)
 	id, err := h.uc.CreateEmployee(r.Context(), employeeData)
 	if err != nil {
-		h.log.Error("create employee", "error", err.Error())
-		utils.Send400(w, messages.BadRequest)
+		h.log.ErrorContext(r.Context(), "create employee", "error", err.Error())
+		utils.SendError(w, err)
 		return
 	}
 
-	h.log.Info("created new employee", "id", id)
+	h.log.InfoContext(r.Context(), "created new employee", "id", id)
 	utils.Send201(w, models.ResponseID{ID: id})
 }
 
 // UpdateEmployee godoc
 // @Summary      Изменить данные сотрудника
-// @Description  Изменить данные о сотруднике
+// @Description  Изменить данные о сотруднике (JSON Merge Patch, RFC 7396): отсутствующие поля не меняются,
+// @Description  manager_id: null убирает руководителя
+// @Description  If-Match с ETag из GET защищает от перезаписи чужих изменений
 // @Tags         employees
 // @Accept       json
+// @Accept       application/merge-patch+json
 // @Produce      json
 // @Param        id path string true "employee id"
-// @Param        request body models.CreateEmployee true "employee data"
+// @Param        If-Match header string false "ETag of the employee"
+// @Param        request body models.UpdateEmployee true "employee data"
 // @Success      200  {object} utils.MessageResponse
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Header       200  {string} ETag "new employee version"
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      412  {object} utils.ErrorResponse
+// @Failure      415  {object} utils.MessageResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      428  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /employees/{id} [patch]
 func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
-	var employeeData *models.CreateEmployee
+	if !utils.HasContentType(r, utils.ContentTypeJSON, utils.ContentTypeMergePatch) {
+		h.log.ErrorContext(r.Context(), "unsupported content type", "content_type", r.Header.Get("Content-Type"))
+		utils.Send415(w, messages.UnsupportedMediaType)
+		return
+	}
+
+	var employeeData *models.UpdateEmployee
 
-	if err := utils.ReadRequestData(r, &employeeData); err != nil {
-		h.log.Error("read request data", "error", err.Error())
+	if err := utils.ReadRequestData(r, &employeeData); err != nil || employeeData == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
@@ -90,101 +127,733 @@ func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	idStr := vars["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.log.Error("parse employee id", "error", err.Error())
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
 
+	version, err := h.readIfMatch(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read if-match", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
 	employeeData.ID = int32(id)
-	h.log.Debug("update employee", "data", employeeData)
-	err = h.uc.EditEmployee(r.Context(), employeeData)
+	employeeData.Version = version
+	h.log.DebugContext(r.Context(), "update employee", "id", id)
+	newVersion, err := h.uc.EditEmployee(r.Context(), employeeData)
 	if err != nil {
-		h.log.Error("edit employee", "error", err.Error())
-		utils.Send400(w, messages.BadRequest)
+		h.log.ErrorContext(r.Context(), "edit employee", "error", err.Error())
+		utils.SendError(w, err)
 		return
 	}
 
-	h.log.Info("updated employee")
+	h.log.InfoContext(r.Context(), "updated employee", "id", id, "version", newVersion)
+	w.Header().Set("ETag", utils.FormatETag(newVersion))
 	utils.Send200(w, utils.MessageResponse{Msg: "employee updated"})
 
 }
 
+// BatchEmployees godoc
+// @Summary      Пакетно изменить сотрудников
+// @Description  Выполнить набор операций create/update/delete в одной транзакции. В режиме atomic (по умолчанию)
+// @Description  ошибка любой операции отменяет весь пакет и возвращается 422, в режиме best_effort ошибочные
+// @Description  операции пропускаются. Для update data — JSON Merge Patch, version — ожидаемая версия сотрудника
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        request body models.BatchRequest true "operations"
+// @Success      200  {object} models.BatchResult
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      415  {object} utils.MessageResponse
+// @Failure      422  {object} models.BatchResult
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees:batch [post]
+func (h *Handler) BatchEmployees(w http.ResponseWriter, r *http.Request) {
+	if !utils.HasContentType(r, utils.ContentTypeJSON) {
+		h.log.ErrorContext(r.Context(), "unsupported content type", "content_type", r.Header.Get("Content-Type"))
+		utils.Send415(w, messages.UnsupportedMediaType)
+		return
+	}
+
+	var request *models.BatchRequest
+	if err := utils.ReadRequestData(r, &request); err != nil || request == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+	if err := decodeBatchData(request); err != nil {
+		h.log.ErrorContext(r.Context(), "read batch operation data", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	result, err := h.uc.BatchEmployees(r.Context(), request)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "batch employees", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+	setBatchErrors(result)
+
+	h.log.InfoContext(r.Context(), "applied employee batch", "mode", result.Mode, "committed", result.Committed,
+		"succeeded", result.Succeeded, "failed", result.Failed)
+	if !result.Committed {
+		utils.SendJSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+	utils.Send200(w, result)
+}
+
+// SearchEmployees godoc
+// @Summary      Найти сотрудников
+// @Description  Полнотекстовый и нечёткий поиск сотрудников по имени, фамилии и телефону
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        q query string true "search query"
+// @Param        company_id query int false "company id"
+// @Param        limit query int false "max results (default 20, max 100)"
+// @Success      200  {object} []models.Employee
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/search [get]
+func (h *Handler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := &models.EmployeeSearchParams{
+		Query: query.Get("q"),
+		Limit: utils.DefaultLimit,
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > utils.MaxLimit {
+			h.log.ErrorContext(r.Context(), "parse limit", "limit", limitStr)
+			utils.Send400(w, messages.BadRequest)
+			return
+		}
+		params.Limit = int32(limit)
+	}
+	if companyIDStr := query.Get("company_id"); companyIDStr != "" {
+		companyID, err := strconv.Atoi(companyIDStr)
+		if err != nil {
+			h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+			utils.Send400(w, messages.BadRequest)
+			return
+		}
+		params.CompanyID = int32(companyID)
+	}
+
+	listEmployees, err := h.uc.SearchEmployees(r.Context(), params)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "search employees", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "found employees", "count", len(listEmployees))
+	utils.Send200(w, listEmployees)
+}
+
+// GetEmployee godoc
+// @Summary      Получить сотрудника
+// @Description  Получить данные сотрудника вместе с отделом
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Success      200  {object} models.Employee
+// @Header       200  {string} ETag "employee version"
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id} [get]
+func (h *Handler) GetEmployee(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	employeeData, err := h.uc.GetEmployee(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get employee", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got employee", "id", id)
+	w.Header().Set("ETag", utils.FormatETag(employeeData.Version))
+	utils.Send200(w, employeeData)
+}
+
 // DeleteEmployee godoc
 // @Summary      Удалить сотрудника
-// @Description  Удалить сотрудника
+// @Description  Перенести сотрудника в архив (мягкое удаление)
 // @Tags         employees
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "employee id"
+// @Param        If-Match header string false "ETag of the employee"
 // @Success      200  {object} utils.MessageResponse
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      412  {object} utils.ErrorResponse
+// @Failure      428  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /employees/{id} [delete]
 func (h *Handler) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.log.Error("parse employee id", "error", err.Error())
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
 
-	if err = h.uc.DeleteEmployee(r.Context(), int32(id)); err != nil {
-		h.log.Error("delete employee", "error", err.Error())
-		utils.Send404(w, messages.NotFound)
+	version, err := h.readIfMatch(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read if-match", "error", err.Error())
+		utils.SendError(w, err)
 		return
 	}
 
-	h.log.Info("deleted employee")
+	if err = h.uc.DeleteEmployee(r.Context(), int32(id), version); err != nil {
+		h.log.ErrorContext(r.Context(), "delete employee", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted employee")
 	utils.Send200(w, utils.MessageResponse{Msg: "employee deleted"})
 }
 
+// GetArchivedEmployees godoc
+// @Summary      Получить архив сотрудников
+// @Description  Вывести удалённых сотрудников постранично, начиная с последних удалённых
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        company_id query int false "company id"
+// @Param        limit query int false "page size (default 20, max 100)"
+// @Param        offset query int false "offset"
+// @Success      200  {object} []models.Employee
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/archived [get]
+func (h *Handler) GetArchivedEmployees(w http.ResponseWriter, r *http.Request) {
+	pagination, err := utils.ReadPagination(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read pagination", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	var companyID int
+	if companyIDStr := r.URL.Query().Get("company_id"); companyIDStr != "" {
+		companyID, err = strconv.Atoi(companyIDStr)
+		if err != nil {
+			h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+			utils.Send400(w, messages.BadRequest)
+			return
+		}
+	}
+
+	listEmployees, err := h.uc.GetArchivedEmployees(r.Context(), int32(companyID), pagination)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get archived employees", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got archived employees", "count", len(listEmployees))
+	utils.Send200(w, listEmployees)
+}
+
+// RestoreEmployee godoc
+// @Summary      Восстановить сотрудника
+// @Description  Вернуть сотрудника из архива
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/restore [post]
+func (h *Handler) RestoreEmployee(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	if err = h.uc.RestoreEmployee(r.Context(), int32(id)); err != nil {
+		h.log.ErrorContext(r.Context(), "restore employee", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "restored employee", "id", id)
+	utils.Send200(w, utils.MessageResponse{Msg: "employee restored"})
+}
+
+// GetEmployeeHistory godoc
+// @Summary      Получить историю изменений сотрудника
+// @Description  Вывести журнал изменений сотрудника постранично, начиная с последних
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Param        limit query int false "page size (default 20, max 100)"
+// @Param        offset query int false "offset"
+// @Success      200  {object} []models.AuditRecord
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/history [get]
+func (h *Handler) GetEmployeeHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	pagination, err := utils.ReadPagination(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read pagination", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	history, err := h.uc.GetEmployeeHistory(r.Context(), int32(id), pagination)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get employee history", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got employee history", "id", id, "count", len(history))
+	utils.Send200(w, history)
+}
+
+// TransferEmployee godoc
+// @Summary      Перевести сотрудника в другой отдел
+// @Description  Перевести сотрудника в отдел той же компании. Перевод с датой сегодня (или без даты) выполняется сразу,
+// @Description  перевод с будущей датой планируется и применяется фоновым обработчиком
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Param        request body models.Transfer true "transfer"
+// @Success      201  {object} models.Assignment
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/transfer [post]
+func (h *Handler) TransferEmployee(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	var transfer *models.Transfer
+	if err = utils.ReadRequestData(r, &transfer); err != nil || transfer == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	transfer.EmployeeID = int32(id)
+	assignment, err := h.uc.TransferEmployee(r.Context(), transfer)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "transfer employee", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "transferred employee", "id", id, "department_id", assignment.DepartmentID,
+		"effective_date", assignment.EffectiveDate, "status", assignment.Status)
+	utils.Send201(w, assignment)
+}
+
+// GetEmployeeAssignments godoc
+// @Summary      Получить историю переводов сотрудника
+// @Description  Вывести отделы сотрудника по датам, включая запланированные и отменённые переводы
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Success      200  {object} []models.Assignment
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/assignments [get]
+func (h *Handler) GetEmployeeAssignments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	assignments, err := h.uc.GetEmployeeAssignments(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get employee assignments", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got employee assignments", "id", id, "count", len(assignments))
+	utils.Send200(w, assignments)
+}
+
+// GetReportingChain godoc
+// @Summary      Получить цепочку руководителей сотрудника
+// @Description  Вывести руководителей сотрудника от непосредственного до верхнего
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Success      200  {object} []models.Employee
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/reporting-chain [get]
+func (h *Handler) GetReportingChain(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	chain, err := h.uc.GetReportingChain(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get reporting chain", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got reporting chain", "id", id, "count", len(chain))
+	utils.Send200(w, chain)
+}
+
+// GetDirectReports godoc
+// @Summary      Получить подчинённых сотрудника
+// @Description  Вывести сотрудников, у которых руководителем указан данный сотрудник
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "employee id"
+// @Success      200  {object} []models.Employee
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /employees/{id}/direct-reports [get]
+func (h *Handler) GetDirectReports(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	reports, err := h.uc.GetDirectReports(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get direct reports", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got direct reports", "id", id, "count", len(reports))
+	utils.Send200(w, reports)
+}
+
+// ImportEmployees godoc
+// @Summary      Импортировать сотрудников компании
+// @Description  Массово создать сотрудников из CSV (с заголовком name,surname,phone,department,passport_type,passport_number)
+// @Description  или JSON Lines. Отдел указывается по названию. Ошибочные строки пропускаются и попадают в отчёт,
+// @Description  с dry_run=true данные только проверяются
+// @Tags         employees
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        id path string true "company id"
+// @Param        dry_run query bool false "validate without creating"
+// @Param        request body string true "CSV or JSON Lines"
+// @Success      200  {object} models.ImportReport
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      415  {object} utils.MessageResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id}/employees:import [post]
+func (h *Handler) ImportEmployees(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			h.log.ErrorContext(r.Context(), "parse dry_run", "error", err.Error())
+			utils.Send400(w, messages.BadRequest)
+			return
+		}
+	}
+
+	var readRows func(io.Reader) ([]*models.ImportEmployee, error)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case utils.ContentTypeCSV:
+		readRows = readImportCSV
+	case utils.ContentTypeNDJSON:
+		readRows = readImportNDJSON
+	default:
+		h.log.ErrorContext(r.Context(), "unsupported content type", "content_type", r.Header.Get("Content-Type"))
+		utils.Send415(w, messages.UnsupportedMediaType)
+		return
+	}
+
+	rows, err := readRows(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read import file", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	report, err := h.uc.ImportEmployees(r.Context(), int32(id), rows, dryRun)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "import employees", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "imported employees", "company_id", id, "dry_run", dryRun, "created", report.Created, "failed", report.Failed)
+	utils.Send200(w, report)
+}
+
+// ExportEmployees godoc
+// @Summary      Выгрузить сотрудников компании
+// @Description  Выгрузить всех сотрудников компании с отделом и паспортными данными в CSV, XLSX или JSON Lines.
+// @Description  Строки читаются из базы курсором и сразу пишутся в ответ
+// @Tags         employees
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Param        id path string true "company id"
+// @Param        format query string false "file format (default csv)" Enums(csv, xlsx, ndjson)
+// @Param        columns query string false "comma separated columns: id,name,surname,phone,company_id,department_id,department_name,department_phone,passport_type,passport_number,created_at"
+// @Success      200  {file} file
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id}/employees/export [get]
+func (h *Handler) ExportEmployees(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		format = export.FormatCSV
+	case export.FormatCSV, export.FormatXLSX, export.FormatNDJSON:
+	default:
+		h.log.ErrorContext(r.Context(), "unknown export format", "format", format)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	columns, err := parseExportColumns(r.URL.Query().Get("columns"))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse export columns", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	exporter := &employeeExporter{
+		w:        w,
+		format:   format,
+		filename: fmt.Sprintf("company-%d-employees", id),
+		columns:  columns,
+	}
+	if err = h.uc.ExportEmployees(r.Context(), int32(id), exporter.write); err != nil {
+		h.log.ErrorContext(r.Context(), "export employees", "error", err.Error())
+		// once the file has started the status is already sent, the client sees a truncated file
+		if exporter.writer == nil {
+			utils.SendError(w, err)
+		}
+		return
+	}
+	if err = exporter.close(); err != nil {
+		h.log.ErrorContext(r.Context(), "finish export", "error", err.Error())
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "exported employees", "company_id", id, "format", format, "count", exporter.rows)
+}
+
 // GetCompanyEmployees godoc
 // @Summary      Получить сотрудников компании
-// @Description  Вывести список сотрудников компании
+// @Description  Вывести список сотрудников компании постранично (keyset-пагинация)
 // @Tags         employees
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "company id"
-// @Success      200  {object} []models.Employee
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Param        limit query int false "page size (default 20, max 100)"
+// @Param        after query string false "next_cursor of the previous page"
+// @Param        sort query string false "sort field" Enums(id, name, surname, created_at)
+// @Param        surname query string false "surname prefix"
+// @Param        passport_type query string false "passport type"
+// @Param        department_id query int false "department id"
+// @Success      200  {object} models.EmployeeList
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /companies/{id}/employees [get]
 func (h *Handler) GetCompanyEmployees(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.log.Error("parse employee id", "error", err.Error())
+		h.log.ErrorContext(r.Context(), "parse employee id", "error", err.Error())
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
 
-	listEmployees, err := h.uc.GetListCompanyEmployees(r.Context(), int32(id))
+	params, err := readEmployeeListParams(r)
 	if err != nil {
-		h.log.Error("get list of employees", "error", err.Error())
-		utils.Send404(w, messages.NotFound)
+		h.log.ErrorContext(r.Context(), "read list params", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
 		return
 	}
+	params.CompanyID = int32(id)
 
-	h.log.Info("got list of company employees")
+	listEmployees, err := h.uc.GetListCompanyEmployees(r.Context(), params)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get list of employees", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got list of company employees")
 	utils.Send200(w, listEmployees)
 }
 
 // GetDepartmentCompanyEmployees godoc
 // @Summary      Получить сотрудников отдела компании
-// @Description  Вывести список сотрудников отдела компании
+// @Description  Вывести список сотрудников отдела компании постранично (keyset-пагинация)
 // @Tags         employees
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "department id"
-// @Success      200  {object} []models.Employee
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Param        limit query int false "page size (default 20, max 100)"
+// @Param        after query string false "next_cursor of the previous page"
+// @Param        sort query string false "sort field" Enums(id, name, surname, created_at)
+// @Param        surname query string false "surname prefix"
+// @Param        passport_type query string false "passport type"
+// @Success      200  {object} models.EmployeeList
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /departments/{id}/employees [get]
 func (h *Handler) GetDepartmentCompanyEmployees(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -192,22 +861,58 @@ func (h *Handler) GetDepartmentCompanyEmployees(w http.ResponseWriter, r *http.R
 	departmentIDStr := vars["id"]
 	departmentID, err := strconv.Atoi(departmentIDStr)
 	if err != nil {
-		h.log.Error("parse department id", "error", err.Error())
+		h.log.ErrorContext(r.Context(), "parse department id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	params, err := readEmployeeListParams(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read list params", "error", err.Error())
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
+	params.DepartmentID = int32(departmentID)
 
-	listEmployees, err := h.uc.GetListDepartmentCompanyEmployees(r.Context(), int32(departmentID))
+	listEmployees, err := h.uc.GetListDepartmentCompanyEmployees(r.Context(), params)
 	if err != nil {
-		h.log.Error("get list of department employees", "error", err.Error())
-		utils.Send404(w, messages.NotFound)
+		h.log.ErrorContext(r.Context(), "get list of department employees", "error", err.Error())
+		utils.SendError(w, err)
 		return
 	}
 
-	h.log.Info("got list of department employees")
+	h.log.InfoContext(r.Context(), "got list of department employees")
 	utils.Send200(w, listEmployees)
 }
 
+func readEmployeeListParams(r *http.Request) (*models.EmployeeListParams, error) {
+	query := r.URL.Query()
+	params := &models.EmployeeListParams{
+		Limit:         utils.DefaultLimit,
+		After:         query.Get("after"),
+		Sort:          query.Get("sort"),
+		SurnamePrefix: query.Get("surname"),
+		PassportType:  query.Get("passport_type"),
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > utils.MaxLimit {
+			return nil, utils.ErrInvalidPagination
+		}
+		params.Limit = int32(limit)
+	}
+	if departmentIDStr := query.Get("department_id"); departmentIDStr != "" {
+		departmentID, err := strconv.Atoi(departmentIDStr)
+		if err != nil {
+			return nil, err
+		}
+		params.DepartmentID = int32(departmentID)
+	}
+
+	return params, nil
+}
+
 // CreateCompany godoc
 // @Summary      Создать компанию
 // @Description  Создать новую компанию
@@ -216,29 +921,250 @@ func (h *Handler) GetDepartmentCompanyEmployees(w http.ResponseWriter, r *http.R
 // @Produce      json
 // @Param        name body models.Company true "company name"
 // @Success      200  {object} models.ResponseID
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /companies [post]
 func (h *Handler) CreateCompany(w http.ResponseWriter, r *http.Request) {
 	var company *models.Company
-	if err := utils.ReadRequestData(r, &company); err != nil {
-		h.log.Error("read request data", "error", err.Error())
+	if err := utils.ReadRequestData(r, &company); err != nil || company == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
 
 	companyID, err := h.uc.CreateCompany(r.Context(), company.Name)
 	if err != nil {
-		h.log.Error("create company", "error", err.Error())
-		utils.Send400(w, messages.BadRequest)
+		h.log.ErrorContext(r.Context(), "create company", "error", err.Error())
+		utils.SendError(w, err)
 		return
 	}
 
-	h.log.Info("created company", "id", companyID)
+	h.log.InfoContext(r.Context(), "created company", "id", companyID)
 	utils.Send201(w, models.ResponseID{ID: companyID})
 }
 
+// GetCompanies godoc
+// @Summary      Получить список компаний
+// @Description  Вывести список компаний постранично
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        limit query int false "page size (default 20, max 100)"
+// @Param        offset query int false "offset"
+// @Success      200  {object} []models.Company
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies [get]
+func (h *Handler) GetCompanies(w http.ResponseWriter, r *http.Request) {
+	pagination, err := utils.ReadPagination(r)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "read pagination", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	companies, err := h.uc.GetCompanies(r.Context(), pagination)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get list of companies", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got list of companies")
+	utils.Send200(w, companies)
+}
+
+// GetCompany godoc
+// @Summary      Получить компанию
+// @Description  Получить компанию по id
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "company id"
+// @Success      200  {object} models.Company
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id} [get]
+func (h *Handler) GetCompany(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	company, err := h.uc.GetCompany(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get company", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got company", "id", id)
+	utils.Send200(w, company)
+}
+
+// UpdateCompany godoc
+// @Summary      Переименовать компанию
+// @Description  Изменить название компании
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "company id"
+// @Param        name body models.Company true "company name"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id} [patch]
+func (h *Handler) UpdateCompany(w http.ResponseWriter, r *http.Request) {
+	var company *models.Company
+	if err := utils.ReadRequestData(r, &company); err != nil || company == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	company.ID = int32(id)
+	if err = h.uc.EditCompany(r.Context(), company); err != nil {
+		h.log.ErrorContext(r.Context(), "edit company", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "updated company", "id", id)
+	utils.Send200(w, utils.MessageResponse{Msg: "company updated"})
+}
+
+// DeleteCompany godoc
+// @Summary      Удалить компанию
+// @Description  Удалить компанию вместе с её отделами. Компанию с сотрудниками (включая архивных) удалить нельзя, возвращается 409
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "company id"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id} [delete]
+func (h *Handler) DeleteCompany(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	if err = h.uc.DeleteCompany(r.Context(), int32(id)); err != nil {
+		h.log.ErrorContext(r.Context(), "delete company", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted company", "id", id)
+	utils.Send200(w, utils.MessageResponse{Msg: "company deleted"})
+}
+
+// GetOrgChart godoc
+// @Summary      Получить оргструктуру компании
+// @Description  Построить дерево компания → отделы → сотрудники в формате JSON, Graphviz DOT или Mermaid
+// @Tags         companies
+// @Produce      json
+// @Produce      text/vnd.graphviz
+// @Produce      plain
+// @Param        id path string true "company id"
+// @Param        format query string false "output format (default json)" Enums(json, dot, mermaid)
+// @Success      200  {object} models.OrgChart
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id}/orgchart [get]
+func (h *Handler) GetOrgChart(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		format = orgchart.FormatJSON
+	case orgchart.FormatJSON, orgchart.FormatDOT, orgchart.FormatMermaid:
+	default:
+		h.log.ErrorContext(r.Context(), "unknown org chart format", "format", format)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	chart, err := h.uc.GetOrgChart(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get org chart", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	var body bytes.Buffer
+	if err = orgchart.Render(&body, format, chart); err != nil {
+		h.log.ErrorContext(r.Context(), "render org chart", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got org chart", "company_id", id, "format", format)
+	w.Header().Set("Content-Type", orgchart.ContentType(format))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
+
 // CreateDepartment godoc
 // @Summary      Создать отдел
 // @Description  Создать новый отдел компании
@@ -247,25 +1173,237 @@ func (h *Handler) CreateCompany(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        request body models.CreateDepartment true "department data"
 // @Success      200  {object} models.ResponseID
-// @Failure      400  {object} string
-// @Failure      404  {object} string
-// @Failure      500  {object} string
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /departments [post]
 func (h *Handler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
 	var department *models.CreateDepartment
-	if err := utils.ReadRequestData(r, &department); err != nil {
-		h.log.Error("read request data", "error", err.Error())
+	if err := utils.ReadRequestData(r, &department); err != nil || department == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
 		utils.Send400(w, messages.BadRequest)
 		return
 	}
 
 	departmentID, err := h.uc.CreateDepartment(r.Context(), department)
 	if err != nil {
-		h.log.Error("create department", "error", err.Error())
-		utils.Send400(w, messages.BadRequest)
+		h.log.ErrorContext(r.Context(), "create department", "error", err.Error())
+		utils.SendError(w, err)
 		return
 	}
 
-	h.log.Info("created department", "id", departmentID)
+	h.log.InfoContext(r.Context(), "created department", "id", departmentID)
 	utils.Send201(w, models.ResponseID{ID: departmentID})
 }
+
+// GetCompanyDepartments godoc
+// @Summary      Получить отделы компании
+// @Description  Вывести список отделов компании
+// @Tags         departments
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "company id"
+// @Success      200  {object} []models.DepartmentInfo
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /companies/{id}/departments [get]
+func (h *Handler) GetCompanyDepartments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse company id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	listDepartments, err := h.uc.GetListCompanyDepartments(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get list of departments", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got list of company departments")
+	utils.Send200(w, listDepartments)
+}
+
+// GetDepartment godoc
+// @Summary      Получить отдел
+// @Description  Получить отдел по id
+// @Tags         departments
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "department id"
+// @Success      200  {object} models.DepartmentInfo
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /departments/{id} [get]
+func (h *Handler) GetDepartment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse department id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	department, err := h.uc.GetDepartment(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get department", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got department", "id", id)
+	utils.Send200(w, department)
+}
+
+// GetDepartmentSubtree godoc
+// @Summary      Получить поддерево отдела
+// @Description  Вывести отдел вместе со всеми вложенными отделами
+// @Tags         departments
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "department id"
+// @Success      200  {object} models.DepartmentTree
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /departments/{id}/subtree [get]
+func (h *Handler) GetDepartmentSubtree(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse department id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	subtree, err := h.uc.GetDepartmentSubtree(r.Context(), int32(id))
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "get department subtree", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "got department subtree", "id", id)
+	utils.Send200(w, subtree)
+}
+
+// UpdateDepartment godoc
+// @Summary      Изменить отдел
+// @Description  Изменить название, телефон или родительский отдел. parent_department_id = 0 делает отдел корневым
+// @Tags         departments
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "department id"
+// @Param        request body models.CreateDepartment true "department data"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      422  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /departments/{id} [patch]
+func (h *Handler) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	var department *models.CreateDepartment
+	if err := utils.ReadRequestData(r, &department); err != nil || department == nil {
+		h.log.ErrorContext(r.Context(), "read request data", "error", err)
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse department id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	department.ID = int32(id)
+	if err = h.uc.EditDepartment(r.Context(), department); err != nil {
+		h.log.ErrorContext(r.Context(), "edit department", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "updated department", "id", id)
+	utils.Send200(w, utils.MessageResponse{Msg: "department updated"})
+}
+
+// DeleteDepartment godoc
+// @Summary      Удалить отдел
+// @Description  Удалить отдел. Отдел с сотрудниками (включая архивных) удалить нельзя, возвращается 409
+// @Tags         departments
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "department id"
+// @Success      200  {object} utils.MessageResponse
+// @Failure      400  {object} utils.MessageResponse
+// @Failure      401  {object} utils.ErrorResponse
+// @Failure      403  {object} utils.ErrorResponse
+// @Failure      404  {object} utils.ErrorResponse
+// @Failure      409  {object} utils.ErrorResponse
+// @Failure      500  {object} utils.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /departments/{id} [delete]
+func (h *Handler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "parse department id", "error", err.Error())
+		utils.Send400(w, messages.BadRequest)
+		return
+	}
+
+	if err = h.uc.DeleteDepartment(r.Context(), int32(id)); err != nil {
+		h.log.ErrorContext(r.Context(), "delete department", "error", err.Error())
+		utils.SendError(w, err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted department", "id", id)
+	utils.Send200(w, utils.MessageResponse{Msg: "department deleted"})
+}
+
+// readIfMatch returns the employee version from If-Match, nil when any version is accepted
+func (h *Handler) readIfMatch(r *http.Request) (*int32, error) {
+	version, ok, err := utils.ReadIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	if !ok && h.cfg.RequireIfMatch {
+		return nil, errs.ErrPreconditionRequired
+	}
+	return version, nil
+}
@@ -2,17 +2,22 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"employees/internal/models"
 	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
 	"employees/internal/pkg/logger"
 	"employees/internal/pkg/utils/messages"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestHandler_CreateCompany(t *testing.T) {
@@ -37,6 +42,13 @@ func TestHandler_CreateCompany(t *testing.T) {
 			expectedCode: http.StatusCreated,
 			expectedBody: `{"id":1}`,
 		},
+		{
+			name:         "null body",
+			inputBody:    `null`,
+			mockBehavior: func(m *mockEmployee.MockUsecase, company models.Company) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
 	}
 
 	for _, tt := range testTable {
@@ -91,6 +103,13 @@ func TestHandler_CreateDepartment(t *testing.T) {
 			expectedCode: http.StatusCreated,
 			expectedBody: `{"id":1}`,
 		},
+		{
+			name:         "null body",
+			inputBody:    `null`,
+			mockBehavior: func(m *mockEmployee.MockUsecase, department *models.CreateDepartment) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
 	}
 	for _, tt := range testTable {
 		t.Run(tt.name, func(t *testing.T) {
@@ -165,8 +184,57 @@ func TestHandler_CreateEmployee(t *testing.T) {
 				DepartmentID: 1,
 			},
 			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.CreateEmployee) {
-				m.EXPECT().CreateEmployee(gomock.Any(), employee).Return(int32(0), fmt.Errorf("Bad request"))
+				m.EXPECT().CreateEmployee(gomock.Any(), employee).Return(int32(0), errs.ErrInvalidReference)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeInvalidReference, messages.InvalidReference),
+		},
+		{
+			name:      "fail: validation",
+			inputBody: `{"company_id": 1,"department_id": 1,"name": "","passport": {"number": "7878 898989",  "type": "РФ"  }, "phone": "93097383","surname": "ivanova"}`,
+			inputEmployee: &models.CreateEmployee{
+				Name:      "",
+				Surname:   "ivanova",
+				Phone:     "93097383",
+				CompanyID: 1,
+				Passport: models.Passport{
+					Number: "7878 898989",
+					Type:   "РФ",
+				},
+				DepartmentID: 1,
+			},
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.CreateEmployee) {
+				m.EXPECT().CreateEmployee(gomock.Any(), employee).Return(int32(0), &errs.ValidationError{
+					Fields: []errs.FieldError{{Field: "name", Message: "is required"}},
+				})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v","fields":[{"field":"name","message":"is required"}]}`, messages.CodeValidation, messages.ValidationFailed),
+		},
+		{
+			name:      "fail: duplicate phone",
+			inputBody: `{"company_id": 1,"department_id": 1,"name": "katya","passport": {"number": "7878 898989",  "type": "РФ"  }, "phone": "93097383","surname": "ivanova"}`,
+			inputEmployee: &models.CreateEmployee{
+				Name:      "katya",
+				Surname:   "ivanova",
+				Phone:     "93097383",
+				CompanyID: 1,
+				Passport: models.Passport{
+					Number: "7878 898989",
+					Type:   "РФ",
+				},
+				DepartmentID: 1,
+			},
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.CreateEmployee) {
+				m.EXPECT().CreateEmployee(gomock.Any(), employee).Return(int32(0), fmt.Errorf("%w: employees_phone_key", errs.ErrConflict))
 			},
+			expectedCode: http.StatusConflict,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeConflict, messages.Conflict),
+		},
+		{
+			name:         "null body",
+			inputBody:    `null`,
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.CreateEmployee) {},
 			expectedCode: http.StatusBadRequest,
 			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
 		},
@@ -198,38 +266,145 @@ func TestHandler_CreateEmployee(t *testing.T) {
 }
 
 func TestHandler_UpdateEmployee(t *testing.T) {
-	type mockBehavior func(m *mockEmployee.MockUsecase, employee *models.CreateEmployee)
+	type mockBehavior func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee)
 
 	testTable := []struct {
-		name          string
-		inputBody     string
-		inputEmployee *models.CreateEmployee
-		employeeID    string
-		mockBehavior  mockBehavior
-		expectedCode  int
-		expectedBody  string
+		name           string
+		inputBody      string
+		contentType    string
+		ifMatch        string
+		requireIfMatch bool
+		inputEmployee  *models.UpdateEmployee
+		employeeID     string
+		mockBehavior   mockBehavior
+		expectedCode   int
+		expectedBody   string
+		expectedETag   string
 	}{
 		{
 			name:      "ok: all fields",
 			inputBody: `{"company_id": 2,"department_id": 2,"name": "ivan","passport": {"number": "1212 343434",  "type": "РБ"  }, "phone": "3413434","surname": "petrov"}`,
-			inputEmployee: &models.CreateEmployee{
+			inputEmployee: &models.UpdateEmployee{
 				ID:        1,
-				Name:      "ivan",
-				Surname:   "petrov",
-				Phone:     "3413434",
-				CompanyID: 2,
-				Passport: models.Passport{
-					Number: "1212 343434",
-					Type:   "РБ",
+				Name:      lo.ToPtr("ivan"),
+				Surname:   lo.ToPtr("petrov"),
+				Phone:     lo.ToPtr("3413434"),
+				CompanyID: lo.ToPtr(int32(2)),
+				Passport: &models.PassportPatch{
+					Number: lo.ToPtr("1212 343434"),
+					Type:   lo.ToPtr("РБ"),
 				},
-				DepartmentID: 2,
+				DepartmentID: lo.ToPtr(int32(2)),
 			},
 			employeeID: "1",
-			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.CreateEmployee) {
-				m.EXPECT().EditEmployee(gomock.Any(), employee).Return(nil)
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {
+				m.EXPECT().EditEmployee(gomock.Any(), employee).Return(int32(2), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"employee updated"}`,
+			expectedETag: `"2"`,
+		},
+		{
+			name:      "ok: matching if-match",
+			inputBody: `{"name": "ivan"}`,
+			ifMatch:   `"4"`,
+			inputEmployee: &models.UpdateEmployee{
+				ID:      1,
+				Name:    lo.ToPtr("ivan"),
+				Version: lo.ToPtr(int32(4)),
+			},
+			requireIfMatch: true,
+			employeeID:     "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {
+				m.EXPECT().EditEmployee(gomock.Any(), employee).Return(int32(5), nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `{"msg":"employee updated"}`,
+			expectedETag: `"5"`,
+		},
+		{
+			name:      "stale if-match",
+			inputBody: `{"name": "ivan"}`,
+			ifMatch:   `"4"`,
+			inputEmployee: &models.UpdateEmployee{
+				ID:      1,
+				Name:    lo.ToPtr("ivan"),
+				Version: lo.ToPtr(int32(4)),
+			},
+			employeeID: "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {
+				m.EXPECT().EditEmployee(gomock.Any(), employee).Return(int32(0), errs.ErrPreconditionFailed)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodePreconditionFailed, messages.PreconditionFailed),
+		},
+		{
+			name:         "weak if-match never matches",
+			inputBody:    `{"name": "ivan"}`,
+			ifMatch:      `W/"4"`,
+			employeeID:   "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodePreconditionFailed, messages.PreconditionFailed),
+		},
+		{
+			name:           "if-match required",
+			inputBody:      `{"name": "ivan"}`,
+			requireIfMatch: true,
+			employeeID:     "1",
+			mockBehavior:   func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {},
+			expectedCode:   http.StatusPreconditionRequired,
+			expectedBody:   fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodePreconditionRequired, messages.PreconditionRequired),
+		},
+		{
+			name:        "ok: merge patch with one field",
+			inputBody:   `{"surname": ""}`,
+			contentType: "application/merge-patch+json",
+			inputEmployee: &models.UpdateEmployee{
+				ID:      1,
+				Surname: lo.ToPtr(""),
+			},
+			employeeID: "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {
+				m.EXPECT().EditEmployee(gomock.Any(), employee).Return(int32(0), &errs.ValidationError{
+					Fields: []errs.FieldError{{Field: "surname", Message: "is required"}},
+				})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v","fields":[{"field":"surname","message":"is required"}]}`, messages.CodeValidation, messages.ValidationFailed),
+		},
+		{
+			name:        "explicit nulls",
+			inputBody:   `{"phone": null, "passport": {"number": null}}`,
+			contentType: "application/merge-patch+json; charset=utf-8",
+			inputEmployee: &models.UpdateEmployee{
+				ID:         1,
+				Passport:   &models.PassportPatch{},
+				NullFields: []string{"phone", "passport.number"},
+			},
+			employeeID: "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {
+				m.EXPECT().EditEmployee(gomock.Any(), employee).Return(int32(0), &errs.ValidationError{})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeValidation, messages.ValidationFailed),
+		},
+		{
+			name:         "unsupported content type",
+			inputBody:    `name=ivan`,
+			contentType:  "application/x-www-form-urlencoded",
+			employeeID:   "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {},
+			expectedCode: http.StatusUnsupportedMediaType,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.UnsupportedMediaType),
+		},
+		{
+			name:         "null document",
+			inputBody:    `null`,
+			employeeID:   "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, employee *models.UpdateEmployee) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
 		},
 	}
 	for _, tt := range testTable {
@@ -241,6 +416,7 @@ func TestHandler_UpdateEmployee(t *testing.T) {
 			tt.mockBehavior(mockUsecaseEmployee, tt.inputEmployee)
 
 			handler := &Handler{
+				cfg: Config{RequireIfMatch: tt.requireIfMatch},
 				uc:  mockUsecaseEmployee,
 				log: logger.SetupLogger(),
 			}
@@ -249,16 +425,58 @@ func TestHandler_UpdateEmployee(t *testing.T) {
 			router.HandleFunc("/employees/{id}", handler.UpdateEmployee)
 
 			rec := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodPut, "/employees/"+tt.employeeID, bytes.NewBufferString(tt.inputBody))
+			req := httptest.NewRequest(http.MethodPatch, "/employees/"+tt.employeeID, bytes.NewBufferString(tt.inputBody))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			router.ServeHTTP(rec, req)
 			assert.Equal(t, tt.expectedCode, rec.Code)
 			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
 		})
 	}
 }
 
-func TestHandler_DeleteEmployee(t *testing.T) {
+func TestHandler_EmployeeDebugLogOmitsPassport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+	mockUsecaseEmployee.EXPECT().CreateEmployee(gomock.Any(), gomock.Any()).Return(int32(1), nil)
+	mockUsecaseEmployee.EXPECT().EditEmployee(gomock.Any(), gomock.Any()).Return(int32(2), nil)
+
+	var output bytes.Buffer
+	handler := &Handler{
+		uc:  mockUsecaseEmployee,
+		log: slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/employees", handler.CreateEmployee)
+	router.HandleFunc("/employees/{id}", handler.UpdateEmployee)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/employees",
+		bytes.NewBufferString(`{"company_id": 1,"department_id": 1,"name": "katya","passport": {"number": "7878 898989", "type": "РФ"}, "phone": "93097383","surname": "ivanova"}`))
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPatch, "/employees/1", bytes.NewBufferString(`{"passport": {"number": "7878 898989"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Contains(t, output.String(), "create employee")
+	assert.Contains(t, output.String(), "update employee")
+	assert.NotContains(t, output.String(), "898989")
+}
+
+func TestHandler_GetEmployee(t *testing.T) {
 	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
 	testTable := []struct {
 		name         string
@@ -267,16 +485,65 @@ func TestHandler_DeleteEmployee(t *testing.T) {
 		mockBehavior mockBehavior
 		expectedCode int
 		expectedBody string
+		expectedETag string
 	}{
 		{
 			name:       "ok",
-			employeeID: "1",
-			ID:         int32(1),
+			employeeID: "5",
+			ID:         int32(5),
 			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
-				m.EXPECT().DeleteEmployee(gomock.Any(), id).Return(nil)
+				m.EXPECT().GetEmployee(gomock.Any(), id).Return(&models.Employee{
+					ID:        5,
+					Name:      "ruslan",
+					Surname:   "ruslanov",
+					Phone:     "89776677",
+					CompanyID: 4,
+					Passport: models.Passport{
+						Type:   "rf",
+						Number: "0989",
+					},
+					Department: models.Department{
+						ID:    2,
+						Name:  "marketing",
+						Phone: "89",
+					},
+					Version: 3,
+				}, nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedBody: `{"msg":"employee deleted"}`,
+			expectedETag: `"3"`,
+			expectedBody: `{
+  "id": 5,
+  "name": "ruslan",
+  "surname": "ruslanov",
+  "phone": "89776677",
+  "company_id": 4,
+  "passport": {
+    "type": "rf",
+    "number": "0989"
+  },
+  "department": {
+    "name": "marketing",
+    "phone": "89"
+  }
+}`,
+		},
+		{
+			name:       "not found",
+			employeeID: "100",
+			ID:         int32(100),
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().GetEmployee(gomock.Any(), id).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:         "bad id",
+			employeeID:   "abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
 		},
 	}
 	for _, tt := range testTable {
@@ -293,137 +560,81 @@ func TestHandler_DeleteEmployee(t *testing.T) {
 			}
 
 			router := mux.NewRouter()
-			router.HandleFunc("/employees/{id}", handler.DeleteEmployee)
+			router.HandleFunc("/employees/{id}", handler.GetEmployee)
 
 			rec := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodDelete, "/employees/"+tt.employeeID, nil)
+			req := httptest.NewRequest(http.MethodGet, "/employees/"+tt.employeeID, nil)
 
 			router.ServeHTTP(rec, req)
 			assert.Equal(t, tt.expectedCode, rec.Code)
 			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
 		})
-
 	}
 }
 
-func TestHandler_GetCompanyEmployees(t *testing.T) {
-	type mockBehavior func(m *mockEmployee.MockUsecase, companyID int32)
+func TestHandler_DeleteEmployee(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
 	testTable := []struct {
-		name         string
-		companyID    int32
-		mockBehavior mockBehavior
-		expectedCode int
-		expectedBody string
+		name           string
+		employeeID     string
+		ID             int32
+		ifMatch        string
+		requireIfMatch bool
+		mockBehavior   mockBehavior
+		expectedCode   int
+		expectedBody   string
 	}{
 		{
-			name:      "ok",
-			companyID: 4,
-			mockBehavior: func(m *mockEmployee.MockUsecase, companyID int32) {
-				m.EXPECT().GetListCompanyEmployees(gomock.Any(), companyID).Return([]*models.Employee{
-					{
-						ID:        5,
-						Name:      "ruslan",
-						Surname:   "ruslanov",
-						Phone:     "89776677",
-						CompanyID: 4,
-						Passport: models.Passport{
-							Type:   "0989",
-							Number: "0989",
-						},
-						Department: models.Department{
-							Name:  "marketing",
-							Phone: "89",
-						},
-					},
-					{
-						ID:        6,
-						Name:      "masha",
-						Surname:   "naumova",
-						Phone:     "12095",
-						CompanyID: 4,
-						Passport: models.Passport{
-							Type:   "rf",
-							Number: "487484",
-						},
-						Department: models.Department{
-							Name:  "marketing",
-							Phone: "89",
-						},
-					},
-					{
-						ID:        7,
-						Name:      "anna",
-						Surname:   "petrova",
-						Phone:     "18385",
-						CompanyID: 4,
-						Passport: models.Passport{
-							Type:   "rf",
-							Number: "407484",
-						},
-						Department: models.Department{
-							Name:  "dev",
-							Phone: "1234",
-						},
-					},
-				}, nil)
+			name:       "ok",
+			employeeID: "1",
+			ID:         int32(1),
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteEmployee(gomock.Any(), id, (*int32)(nil)).Return(nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedBody: `[
-  {
-    "id": 5,
-    "name": "ruslan",
-    "surname": "ruslanov",
-    "phone": "89776677",
-    "company_id": 4,
-    "passport": {
-      "type": "0989",
-      "number": "0989"
-    },
-    "department": {
-      "name": "marketing",
-      "phone": "89"
-    }
-  },
-  {
-    "id": 6,
-    "name": "masha",
-    "surname": "naumova",
-    "phone": "12095",
-    "company_id": 4,
-    "passport": {
-      "type": "rf",
-      "number": "487484"
-    },
-    "department": {
-      "name": "marketing",
-      "phone": "89"
-    }
-  },
-  {
-    "id": 7,
-    "name": "anna",
-    "surname": "petrova",
-    "phone": "18385",
-    "company_id": 4,
-    "passport": {
-      "type": "rf",
-      "number": "407484"
-    },
-    "department": {
-      "name": "dev",
-      "phone": "1234"
-    }
-  }
-]`,
+			expectedBody: `{"msg":"employee deleted"}`,
 		},
 		{
-			name:      "empty",
-			companyID: 10,
-			mockBehavior: func(m *mockEmployee.MockUsecase, companyID int32) {
-				m.EXPECT().GetListCompanyEmployees(gomock.Any(), companyID).Return([]*models.Employee{}, nil)
+			name:           "ok: any version",
+			employeeID:     "1",
+			ID:             int32(1),
+			ifMatch:        "*",
+			requireIfMatch: true,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteEmployee(gomock.Any(), id, (*int32)(nil)).Return(nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedBody: "[]",
+			expectedBody: `{"msg":"employee deleted"}`,
+		},
+		{
+			name:       "stale if-match",
+			employeeID: "1",
+			ID:         int32(1),
+			ifMatch:    `"7"`,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteEmployee(gomock.Any(), id, lo.ToPtr(int32(7))).Return(errs.ErrPreconditionFailed)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodePreconditionFailed, messages.PreconditionFailed),
+		},
+		{
+			name:           "if-match required",
+			employeeID:     "1",
+			requireIfMatch: true,
+			mockBehavior:   func(m *mockEmployee.MockUsecase, id int32) {},
+			expectedCode:   http.StatusPreconditionRequired,
+			expectedBody:   fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodePreconditionRequired, messages.PreconditionRequired),
+		},
+		{
+			name:       "fail: database unavailable",
+			employeeID: "1",
+			ID:         int32(1),
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteEmployee(gomock.Any(), id, (*int32)(nil)).Return(fmt.Errorf("connection refused"))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeInternal, messages.InternalServerError),
 		},
 	}
 	for _, tt := range testTable {
@@ -432,114 +643,416 @@ func TestHandler_GetCompanyEmployees(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
-			tt.mockBehavior(mockUsecaseEmployee, tt.companyID)
+			tt.mockBehavior(mockUsecaseEmployee, tt.ID)
 
 			handler := &Handler{
+				cfg: Config{RequireIfMatch: tt.requireIfMatch},
 				uc:  mockUsecaseEmployee,
 				log: logger.SetupLogger(),
 			}
 
 			router := mux.NewRouter()
-			router.HandleFunc("/companies/{id}/employees", handler.GetCompanyEmployees)
+			router.HandleFunc("/employees/{id}", handler.DeleteEmployee)
 
 			rec := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/companies/%v/employees", tt.companyID), nil)
+			req := httptest.NewRequest(http.MethodDelete, "/employees/"+tt.employeeID, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			router.ServeHTTP(rec, req)
 			assert.Equal(t, tt.expectedCode, rec.Code)
 			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
 		})
+
 	}
 }
 
-func TestHandler_GetDepartmentCompanyEmployees(t *testing.T) {
-	type mockBehavior func(m *mockEmployee.MockUsecase, departmentID int32)
+func TestHandler_GetArchivedEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, pagination *models.Pagination)
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
 	testTable := []struct {
-		name         string
-		departmentID int32
-		mockBehavior mockBehavior
-		expectedCode int
-		expectedBody string
+		name            string
+		query           string
+		companyID       int32
+		inputPagination *models.Pagination
+		mockBehavior    mockBehavior
+		expectedCode    int
+		expectedBody    string
 	}{
 		{
-			name:         "ok",
-			departmentID: 2,
-			mockBehavior: func(m *mockEmployee.MockUsecase, departmentID int32) {
-				m.EXPECT().GetListDepartmentCompanyEmployees(gomock.Any(), departmentID).Return([]*models.Employee{
-					{
-						ID:        5,
-						Name:      "ruslan",
-						Surname:   "ruslanov",
-						Phone:     "89776677",
-						CompanyID: 4,
-						Passport: models.Passport{
-							Type:   "0989",
-							Number: "0989",
-						},
-						Department: models.Department{
-							Name:  "marketing",
-							Phone: "89",
-						},
-					},
+			name:            "ok",
+			query:           "?company_id=4&limit=1",
+			inputPagination: &models.Pagination{Limit: 1, Offset: 0},
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {
+				m.EXPECT().GetArchivedEmployees(gomock.Any(), int32(4), pagination).Return([]*models.Employee{
 					{
-						ID:        6,
-						Name:      "masha",
-						Surname:   "naumova",
-						Phone:     "12095",
-						CompanyID: 4,
-						Passport: models.Passport{
-							Type:   "rf",
-							Number: "487484",
-						},
-						Department: models.Department{
-							Name:  "marketing",
-							Phone: "89",
-						},
+						ID:         5,
+						Name:       "ruslan",
+						Surname:    "ruslanov",
+						Phone:      "89776677",
+						CompanyID:  4,
+						Passport:   models.Passport{Type: "rf", Number: "0989"},
+						Department: models.Department{ID: 2, Name: "marketing", Phone: "89"},
+						DeletedAt:  &deletedAt,
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":5,"name":"ruslan","surname":"ruslanov","phone":"89776677","company_id":4,
+"passport":{"type":"rf","number":"0989"},"department":{"name":"marketing","phone":"89"},"deleted_at":"2024-03-01T12:00:00Z"}]`,
+		},
+		{
+			name:            "ok: empty archive",
+			inputPagination: &models.Pagination{Limit: 20, Offset: 0},
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {
+				m.EXPECT().GetArchivedEmployees(gomock.Any(), int32(0), pagination).Return([]*models.Employee{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:         "bad company id",
+			query:        "?company_id=abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputPagination)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/archived", handler.GetArchivedEmployees)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/employees/archived"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_RestoreEmployee(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
+	testTable := []struct {
+		name         string
+		employeeID   string
+		ID           int32
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:       "ok",
+			employeeID: "1",
+			ID:         int32(1),
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().RestoreEmployee(gomock.Any(), id).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"employee restored"}`,
+		},
+		{
+			name:       "not archived",
+			employeeID: "1",
+			ID:         int32(1),
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().RestoreEmployee(gomock.Any(), id).Return(errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:       "phone taken by an active employee",
+			employeeID: "1",
+			ID:         int32(1),
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().RestoreEmployee(gomock.Any(), id).Return(errs.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeConflict, messages.Conflict),
+		},
+		{
+			name:         "bad id",
+			employeeID:   "abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.ID)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/restore", handler.RestoreEmployee)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/employees/"+tt.employeeID+"/restore", nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetEmployeeHistory(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, pagination *models.Pagination)
+	changedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	testTable := []struct {
+		name            string
+		employeeID      string
+		query           string
+		inputPagination *models.Pagination
+		mockBehavior    mockBehavior
+		expectedCode    int
+		expectedBody    string
+	}{
+		{
+			name:            "ok",
+			employeeID:      "5",
+			query:           "?limit=2",
+			inputPagination: &models.Pagination{Limit: 2, Offset: 0},
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {
+				m.EXPECT().GetEmployeeHistory(gomock.Any(), int32(5), pagination).Return([]*models.AuditRecord{
+					{
+						ID:            2,
+						Operation:     models.AuditUpdate,
+						Actor:         "hr",
+						Before:        []byte(`{"name":"katya"}`),
+						After:         []byte(`{"name":"ekaterina"}`),
+						ChangedFields: []string{"name"},
+						CreatedAt:     changedAt,
+					},
+					{
+						ID:            1,
+						Operation:     models.AuditCreate,
+						Actor:         "anonymous",
+						After:         []byte(`{"name":"katya"}`),
+						ChangedFields: []string{"name"},
+						CreatedAt:     changedAt,
 					},
 				}, nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `[
-  {
-    "id": 5,
-    "name": "ruslan",
-    "surname": "ruslanov",
-    "phone": "89776677",
-    "company_id": 4,
-    "passport": {
-      "type": "0989",
-      "number": "0989"
-    },
-    "department": {
-      "name": "marketing",
-      "phone": "89"
-    }
-  },
-  {
-    "id": 6,
-    "name": "masha",
-    "surname": "naumova",
-    "phone": "12095",
-    "company_id": 4,
-    "passport": {
-      "type": "rf",
-      "number": "487484"
-    },
-    "department": {
-      "name": "marketing",
-      "phone": "89"
-    }
-  }
+{"id":2,"operation":"update","actor":"hr","before":{"name":"katya"},"after":{"name":"ekaterina"},"changed_fields":["name"],"created_at":"2024-03-01T12:00:00Z"},
+{"id":1,"operation":"create","actor":"anonymous","before":null,"after":{"name":"katya"},"changed_fields":["name"],"created_at":"2024-03-01T12:00:00Z"}
 ]`,
 		},
 		{
-			name:         "empty",
-			departmentID: 122,
-			mockBehavior: func(m *mockEmployee.MockUsecase, departmentID int32) {
-				m.EXPECT().GetListDepartmentCompanyEmployees(gomock.Any(), departmentID).Return([]*models.Employee{}, nil)
+			name:         "bad id",
+			employeeID:   "abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name:         "bad pagination",
+			employeeID:   "5",
+			query:        "?offset=-1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputPagination)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/history", handler.GetEmployeeHistory)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/employees/"+tt.employeeID+"/history"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_TransferEmployee(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		employeeID   string
+		body         string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:       "scheduled",
+			employeeID: "5",
+			body:       `{"department_id":3,"effective_date":"2024-05-01","reason":"reorg"}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().TransferEmployee(gomock.Any(), &models.Transfer{
+					EmployeeID:    5,
+					DepartmentID:  3,
+					EffectiveDate: "2024-05-01",
+					Reason:        "reorg",
+				}).Return(&models.Assignment{
+					ID:               7,
+					EmployeeID:       5,
+					FromDepartmentID: lo.ToPtr(int32(2)),
+					DepartmentID:     3,
+					EffectiveDate:    "2024-05-01",
+					Status:           models.AssignmentScheduled,
+					Reason:           "reorg",
+					Actor:            "hr",
+					CreatedAt:        createdAt,
+				}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":7,"employee_id":5,"from_department_id":2,"department_id":3,"effective_date":"2024-05-01",
+"status":"scheduled","reason":"reorg","actor":"hr","created_at":"2024-03-01T12:00:00Z"}`,
+		},
+		{
+			name:       "employee not found",
+			employeeID: "5",
+			body:       `{"department_id":3}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().TransferEmployee(gomock.Any(), gomock.Any()).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"not_found","msg":"%v"}`, messages.NotFound),
+		},
+		{
+			name:         "bad body",
+			employeeID:   "5",
+			body:         `{"department_id":"3"}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name:         "bad id",
+			employeeID:   "abc",
+			body:         `{"department_id":3}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/transfer", handler.TransferEmployee)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/employees/"+tt.employeeID+"/transfer", bytes.NewBufferString(tt.body))
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetEmployeeAssignments(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		employeeID   string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:       "ok",
+			employeeID: "5",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetEmployeeAssignments(gomock.Any(), int32(5)).Return([]*models.Assignment{
+					{
+						ID:             1,
+						EmployeeID:     5,
+						DepartmentID:   2,
+						DepartmentName: "dev",
+						EffectiveDate:  "2024-03-01",
+						Status:         models.AssignmentApplied,
+						Actor:          "hr",
+						CreatedAt:      createdAt,
+						AppliedAt:      &createdAt,
+					},
+					{
+						ID:                 2,
+						EmployeeID:         5,
+						FromDepartmentID:   lo.ToPtr(int32(2)),
+						FromDepartmentName: "dev",
+						DepartmentID:       3,
+						DepartmentName:     "sales",
+						EffectiveDate:      "2024-05-01",
+						Status:             models.AssignmentScheduled,
+						Actor:              "hr",
+						CreatedAt:          createdAt,
+					},
+				}, nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedBody: "[]",
+			expectedBody: `[
+{"id":1,"employee_id":5,"department_id":2,"department_name":"dev","effective_date":"2024-03-01","status":"applied",
+"actor":"hr","created_at":"2024-03-01T12:00:00Z","applied_at":"2024-03-01T12:00:00Z"},
+{"id":2,"employee_id":5,"from_department_id":2,"from_department_name":"dev","department_id":3,"department_name":"sales",
+"effective_date":"2024-05-01","status":"scheduled","actor":"hr","created_at":"2024-03-01T12:00:00Z"}
+]`,
+		},
+		{
+			name:       "employee not found",
+			employeeID: "5",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetEmployeeAssignments(gomock.Any(), int32(5)).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"not_found","msg":"%v"}`, messages.NotFound),
 		},
 	}
 	for _, tt := range testTable {
@@ -548,7 +1061,7 @@ func TestHandler_GetDepartmentCompanyEmployees(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
-			tt.mockBehavior(mockUsecaseEmployee, tt.departmentID)
+			tt.mockBehavior(mockUsecaseEmployee)
 
 			handler := &Handler{
 				uc:  mockUsecaseEmployee,
@@ -556,10 +1069,1758 @@ func TestHandler_GetDepartmentCompanyEmployees(t *testing.T) {
 			}
 
 			router := mux.NewRouter()
-			router.HandleFunc("/departments/{id}/employees", handler.GetDepartmentCompanyEmployees)
+			router.HandleFunc("/employees/{id}/assignments", handler.GetEmployeeAssignments)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/employees/"+tt.employeeID+"/assignments", nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetReportingChain(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	testTable := []struct {
+		name         string
+		employeeID   string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:       "ok",
+			employeeID: "5",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetReportingChain(gomock.Any(), int32(5)).Return([]*models.Employee{
+					{
+						ID:         3,
+						Name:       "ivan",
+						Surname:    "petrov",
+						Phone:      "+79160000000",
+						CompanyID:  1,
+						Passport:   models.Passport{Type: "РФ", Number: "1111 222222"},
+						Department: models.Department{Name: "dev", Phone: "1234"},
+						ManagerID:  lo.ToPtr(int32(1)),
+					},
+					{
+						ID:         1,
+						Name:       "anna",
+						Surname:    "smirnova",
+						Phone:      "+79161111111",
+						CompanyID:  1,
+						Passport:   models.Passport{Type: "РФ", Number: "3333 444444"},
+						Department: models.Department{Name: "board", Phone: "5678"},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[
+{"id":3,"name":"ivan","surname":"petrov","phone":"+79160000000","company_id":1,"passport":{"type":"РФ","number":"1111 222222"},
+"department":{"name":"dev","phone":"1234"},"manager_id":1},
+{"id":1,"name":"anna","surname":"smirnova","phone":"+79161111111","company_id":1,"passport":{"type":"РФ","number":"3333 444444"},
+"department":{"name":"board","phone":"5678"}}
+]`,
+		},
+		{
+			name:       "employee not found",
+			employeeID: "5",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetReportingChain(gomock.Any(), int32(5)).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"not_found","msg":"%v"}`, messages.NotFound),
+		},
+		{
+			name:         "bad id",
+			employeeID:   "abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/reporting-chain", handler.GetReportingChain)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/employees/"+tt.employeeID+"/reporting-chain", nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetDirectReports(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetDirectReports(gomock.Any(), int32(1)).Return([]*models.Employee{
+					{
+						ID:         3,
+						Name:       "ivan",
+						Surname:    "petrov",
+						Phone:      "+79160000000",
+						CompanyID:  1,
+						Passport:   models.Passport{Type: "РФ", Number: "1111 222222"},
+						Department: models.Department{Name: "dev", Phone: "1234"},
+						ManagerID:  lo.ToPtr(int32(1)),
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":3,"name":"ivan","surname":"petrov","phone":"+79160000000","company_id":1,
+"passport":{"type":"РФ","number":"1111 222222"},"department":{"name":"dev","phone":"1234"},"manager_id":1}]`,
+		},
+		{
+			name: "no reports",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetDirectReports(gomock.Any(), int32(1)).Return([]*models.Employee{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name: "employee not found",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetDirectReports(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"not_found","msg":"%v"}`, messages.NotFound),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/direct-reports", handler.GetDirectReports)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/employees/1/direct-reports", nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetEmployeeDocuments(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetEmployeeDocuments(gomock.Any(), int32(1)).Return([]*models.IdentityDocument{
+					{ID: 1, EmployeeID: 1, Type: "РФ", Number: "4510 123456", Primary: true},
+					{ID: 2, EmployeeID: 1, Type: "загран", Number: "75 1234567", Issuer: "МВД 77001", IssueDate: "2020-01-15", ExpiryDate: "2030-01-15", Country: "RU"},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":1,"employee_id":1,"type":"РФ","number":"4510 123456","primary":true},
+{"id":2,"employee_id":1,"type":"загран","number":"75 1234567","issuer":"МВД 77001","issue_date":"2020-01-15",
+"expiry_date":"2030-01-15","country":"RU","primary":false}]`,
+		},
+		{
+			name: "employee not found",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetEmployeeDocuments(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"not_found","msg":"%v"}`, messages.NotFound),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/documents", handler.GetEmployeeDocuments)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/employees/1/documents", nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_CreateEmployeeDocument(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	document := &models.CreateIdentityDocument{EmployeeID: 1, Type: "загран", Number: "75 1234567", Country: "RU", Primary: true}
+
+	testTable := []struct {
+		name         string
+		inputBody    string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"type":"загран","number":"75 1234567","country":"RU","primary":true}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().CreateEmployeeDocument(gomock.Any(), document).Return(int32(5), nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":5}`,
+		},
+		{
+			name:      "type and number taken",
+			inputBody: `{"type":"загран","number":"75 1234567","country":"RU","primary":true}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().CreateEmployeeDocument(gomock.Any(), document).
+					Return(int32(0), fmt.Errorf("%w: identity_documents_type_number_key", errs.ErrConflict))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeConflict, messages.Conflict),
+		},
+		{
+			name:         "bad body",
+			inputBody:    `{"type":`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/documents", handler.CreateEmployeeDocument)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/employees/1/documents", bytes.NewBufferString(tt.inputBody))
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteEmployeeDocument(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	testTable := []struct {
+		name         string
+		path         string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "ok",
+			path: "/employees/1/documents/2",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().DeleteEmployeeDocument(gomock.Any(), int32(1), int32(2)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"document deleted"}`,
+		},
+		{
+			name: "primary document",
+			path: "/employees/1/documents/2",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().DeleteEmployeeDocument(gomock.Any(), int32(1), int32(2)).
+					Return(fmt.Errorf("%w: identity document 2 is the primary one", errs.ErrConflict))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeConflict, messages.Conflict),
+		},
+		{
+			name:         "bad document id",
+			path:         "/employees/1/documents/abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/{id}/documents/{documentId}", handler.DeleteEmployeeDocument)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_ImportEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	row := &models.ImportEmployee{
+		Line:       2,
+		Name:       "katya",
+		Surname:    "ivanova",
+		Phone:      "+79161234567",
+		Department: "dev",
+		Passport:   models.Passport{Type: "РФ", Number: "7878 898989"},
+	}
+
+	testTable := []struct {
+		name         string
+		query        string
+		contentType  string
+		inputBody    string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "ok: csv",
+			contentType: "text/csv; charset=utf-8",
+			inputBody:   "name,surname,phone,department,passport_type,passport_number\nkatya,ivanova,+79161234567,dev,РФ,7878 898989\n",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().ImportEmployees(gomock.Any(), int32(3), []*models.ImportEmployee{row}, false).Return(&models.ImportReport{
+					Total:   1,
+					Created: 1,
+					Rows:    []models.ImportRowResult{{Line: 2, Status: models.ImportCreated, ID: 10}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"dry_run":false,"total":1,"created":1,"failed":0,"rows":[{"line":2,"status":"created","id":10}]}`,
+		},
+		{
+			name:        "ok: ndjson dry run",
+			query:       "?dry_run=true",
+			contentType: "application/x-ndjson",
+			inputBody:   `{"name":"katya","surname":"ivanova","phone":"+79161234567","department":"dev","passport":{"type":"РФ","number":"7878 898989"}}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				ndjsonRow := *row
+				ndjsonRow.Line = 1
+				m.EXPECT().ImportEmployees(gomock.Any(), int32(3), []*models.ImportEmployee{&ndjsonRow}, true).Return(&models.ImportReport{
+					DryRun: true,
+					Total:  1,
+					Rows:   []models.ImportRowResult{{Line: 1, Status: models.ImportValid}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"dry_run":true,"total":1,"created":0,"failed":0,"rows":[{"line":1,"status":"valid"}]}`,
+		},
+		{
+			name:        "company not found",
+			contentType: "application/x-ndjson",
+			inputBody:   `{"name":"katya"}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().ImportEmployees(gomock.Any(), int32(3), gomock.Any(), false).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:         "missing csv column",
+			contentType:  "text/csv",
+			inputBody:    "name,surname\nkatya,ivanova\n",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name:         "bad dry_run",
+			query:        "?dry_run=maybe",
+			contentType:  "text/csv",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name:         "unsupported content type",
+			contentType:  "application/json",
+			inputBody:    `[]`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusUnsupportedMediaType,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.UnsupportedMediaType),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}/employees:import", handler.ImportEmployees)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/companies/3/employees:import"+tt.query, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_ExportEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	employees := []*models.Employee{
+		{
+			ID:         1,
+			Name:       "katya",
+			Surname:    "ivanova",
+			Phone:      "+79161234567",
+			CompanyID:  3,
+			Passport:   models.Passport{Type: "РФ", Number: "7878 898989"},
+			Department: models.Department{ID: 2, Name: "dev", Phone: "+74951234567"},
+			CreatedAt:  time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:         4,
+			Name:       "ivan",
+			Surname:    "petrov",
+			Phone:      "+79161234568",
+			CompanyID:  3,
+			Passport:   models.Passport{Type: "РФ", Number: "7878 898988"},
+			Department: models.Department{ID: 2, Name: "dev", Phone: "+74951234567"},
+			CreatedAt:  time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	streamEmployees := func(m *mockEmployee.MockUsecase) {
+		m.EXPECT().ExportEmployees(gomock.Any(), int32(3), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int32, fn func(*models.Employee) error) error {
+				for _, employee := range employees {
+					if err := fn(employee); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	}
+
+	testTable := []struct {
+		name                string
+		companyID           string
+		query               string
+		mockBehavior        mockBehavior
+		expectedCode        int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "csv by default",
+			companyID:           "3",
+			mockBehavior:        streamEmployees,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: `id,name,surname,phone,company_id,department_id,department_name,department_phone,passport_type,passport_number,created_at
+1,katya,ivanova,+79161234567,3,2,dev,+74951234567,РФ,7878 898989,2024-03-01T12:00:00Z
+4,ivan,petrov,+79161234568,3,2,dev,+74951234567,РФ,7878 898988,2024-03-02T12:00:00Z
+`,
+		},
+		{
+			name:                "ndjson with columns",
+			companyID:           "3",
+			query:               "?format=ndjson&columns=surname,department_name,passport_number",
+			mockBehavior:        streamEmployees,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: `{"surname":"ivanova","department_name":"dev","passport_number":"7878 898989"}
+{"surname":"petrov","department_name":"dev","passport_number":"7878 898988"}
+`,
+		},
+		{
+			name:      "no employees",
+			companyID: "3",
+			query:     "?columns=id,name",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().ExportEmployees(gomock.Any(), int32(3), gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "id,name\n",
+		},
+		{
+			name:      "company not found",
+			companyID: "3",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().ExportEmployees(gomock.Any(), int32(3), gomock.Any()).Return(errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"not_found","msg":"%v"}`, messages.NotFound),
+		},
+		{
+			name:         "unknown format",
+			companyID:    "3",
+			query:        "?format=pdf",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name:         "unknown column",
+			companyID:    "3",
+			query:        "?columns=name,salary",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}/employees/export", handler.ExportEmployees)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/companies/"+tt.companyID+"/employees/export"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedContentType == "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+				return
+			}
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_BatchEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+
+	testTable := []struct {
+		name         string
+		body         string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "committed",
+			body: `{"mode":"best_effort","operations":[
+{"op":"create","data":{"name":"katya","surname":"ivanova","phone":"+79161234567","company_id":1,"department_id":2,"passport":{"type":"РФ","number":"7878 898989"}}},
+{"op":"update","id":5,"version":2,"data":{"surname":"petrova","phone":null}},
+{"op":"delete","id":6}]}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().BatchEmployees(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *models.BatchRequest) (*models.BatchResult, error) {
+						assert.Equal(t, "katya", request.Operations[0].Create.Name)
+						assert.Equal(t, lo.ToPtr("petrova"), request.Operations[1].Update.Surname)
+						assert.Equal(t, []string{"phone"}, request.Operations[1].Update.NullFields)
+						assert.Equal(t, lo.ToPtr(int32(2)), request.Operations[1].Version)
+						assert.Nil(t, request.Operations[2].Update)
+
+						return &models.BatchResult{
+							Mode:      models.BatchBestEffort,
+							Committed: true,
+							Succeeded: 2,
+							Failed:    1,
+							Results: []*models.BatchOperationResult{
+								{Index: 0, Op: models.BatchCreate, Status: models.BatchOK, ID: 10},
+								{Index: 1, Op: models.BatchUpdate, Status: models.BatchFailed, ID: 5, Err: &errs.ValidationError{
+									Fields: []errs.FieldError{{Field: "phone", Message: "cannot be null"}},
+								}},
+								{Index: 2, Op: models.BatchDelete, Status: models.BatchOK, ID: 6},
+							},
+						}, nil
+					})
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"mode":"best_effort","committed":true,"succeeded":2,"failed":1,"results":[
+{"index":0,"op":"create","status":"ok","id":10},
+{"index":1,"op":"update","status":"failed","id":5,"error":{"status":422,"code":"validation_failed","msg":"Validation Failed","fields":[{"field":"phone","message":"cannot be null"}]}},
+{"index":2,"op":"delete","status":"ok","id":6}]}`,
+		},
+		{
+			name: "rolled back",
+			body: `{"operations":[{"op":"delete","id":6},{"op":"delete","id":7}]}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().BatchEmployees(gomock.Any(), gomock.Any()).Return(&models.BatchResult{
+					Mode:   models.BatchAtomic,
+					Failed: 1,
+					Results: []*models.BatchOperationResult{
+						{Index: 0, Op: models.BatchDelete, Status: models.BatchRolledBack, ID: 6},
+						{Index: 1, Op: models.BatchDelete, Status: models.BatchFailed, ID: 7, Err: errs.ErrNotFound},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"mode":"atomic","committed":false,"succeeded":0,"failed":1,"results":[
+{"index":0,"op":"delete","status":"rolled_back","id":6},
+{"index":1,"op":"delete","status":"failed","id":7,"error":{"status":404,"code":"not_found","msg":"Not Found"}}]}`,
+		},
+		{
+			name:         "malformed operation data",
+			body:         `{"operations":[{"op":"create","data":{"name":5}}]}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name: "invalid request",
+			body: `{"mode":"all","operations":[]}`,
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().BatchEmployees(gomock.Any(), gomock.Any()).Return(nil, &errs.ValidationError{
+					Fields: []errs.FieldError{{Field: "mode", Message: "must be atomic or best_effort"}},
+				})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"code":"validation_failed","msg":"Validation Failed","fields":[{"field":"mode","message":"must be atomic or best_effort"}]}`,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees:batch", handler.BatchEmployees)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/employees:batch", bytes.NewBufferString(tt.body))
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetCompanyEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, params *models.EmployeeListParams)
+	testTable := []struct {
+		name         string
+		query        string
+		inputParams  *models.EmployeeListParams
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "ok",
+			query:       "?limit=3&sort=surname&surname=iv",
+			inputParams: &models.EmployeeListParams{CompanyID: 4, Limit: 3, Sort: "surname", SurnamePrefix: "iv"},
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeListParams) {
+				m.EXPECT().GetListCompanyEmployees(gomock.Any(), params).Return(&models.EmployeeList{
+					Total:      lo.ToPtr(int64(3)),
+					NextCursor: "next",
+					Employees: []*models.Employee{
+						{
+							ID:        5,
+							Name:      "ruslan",
+							Surname:   "ruslanov",
+							Phone:     "89776677",
+							CompanyID: 4,
+							Passport: models.Passport{
+								Type:   "0989",
+								Number: "0989",
+							},
+							Department: models.Department{
+								Name:  "marketing",
+								Phone: "89",
+							},
+						},
+						{
+							ID:        6,
+							Name:      "masha",
+							Surname:   "naumova",
+							Phone:     "12095",
+							CompanyID: 4,
+							Passport: models.Passport{
+								Type:   "rf",
+								Number: "487484",
+							},
+							Department: models.Department{
+								Name:  "marketing",
+								Phone: "89",
+							},
+						},
+						{
+							ID:        7,
+							Name:      "anna",
+							Surname:   "petrova",
+							Phone:     "18385",
+							CompanyID: 4,
+							Passport: models.Passport{
+								Type:   "rf",
+								Number: "407484",
+							},
+							Department: models.Department{
+								Name:  "dev",
+								Phone: "1234",
+							},
+						},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+  "total": 3,
+  "next_cursor": "next",
+  "employees": [
+  {
+    "id": 5,
+    "name": "ruslan",
+    "surname": "ruslanov",
+    "phone": "89776677",
+    "company_id": 4,
+    "passport": {
+      "type": "0989",
+      "number": "0989"
+    },
+    "department": {
+      "name": "marketing",
+      "phone": "89"
+    }
+  },
+  {
+    "id": 6,
+    "name": "masha",
+    "surname": "naumova",
+    "phone": "12095",
+    "company_id": 4,
+    "passport": {
+      "type": "rf",
+      "number": "487484"
+    },
+    "department": {
+      "name": "marketing",
+      "phone": "89"
+    }
+  },
+  {
+    "id": 7,
+    "name": "anna",
+    "surname": "petrova",
+    "phone": "18385",
+    "company_id": 4,
+    "passport": {
+      "type": "rf",
+      "number": "407484"
+    },
+    "department": {
+      "name": "dev",
+      "phone": "1234"
+    }
+  }
+]}`,
+		},
+		{
+			name:        "empty",
+			inputParams: &models.EmployeeListParams{CompanyID: 4, Limit: 20},
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeListParams) {
+				m.EXPECT().GetListCompanyEmployees(gomock.Any(), params).Return(&models.EmployeeList{Employees: []*models.Employee{}, Total: lo.ToPtr(int64(0))}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"employees":[],"total":0}`,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputParams)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}/employees", handler.GetCompanyEmployees)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/companies/4/employees"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetDepartmentCompanyEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, params *models.EmployeeListParams)
+	testTable := []struct {
+		name         string
+		query        string
+		inputParams  *models.EmployeeListParams
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "ok",
+			query:       "?passport_type=rf&after=abc",
+			inputParams: &models.EmployeeListParams{DepartmentID: 2, Limit: 20, PassportType: "rf", After: "abc"},
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeListParams) {
+				m.EXPECT().GetListDepartmentCompanyEmployees(gomock.Any(), params).Return(&models.EmployeeList{
+					Total:      lo.ToPtr(int64(2)),
+					NextCursor: "next",
+					Employees: []*models.Employee{
+						{
+							ID:        5,
+							Name:      "ruslan",
+							Surname:   "ruslanov",
+							Phone:     "89776677",
+							CompanyID: 4,
+							Passport: models.Passport{
+								Type:   "0989",
+								Number: "0989",
+							},
+							Department: models.Department{
+								Name:  "marketing",
+								Phone: "89",
+							},
+						},
+						{
+							ID:        6,
+							Name:      "masha",
+							Surname:   "naumova",
+							Phone:     "12095",
+							CompanyID: 4,
+							Passport: models.Passport{
+								Type:   "rf",
+								Number: "487484",
+							},
+							Department: models.Department{
+								Name:  "marketing",
+								Phone: "89",
+							},
+						},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+  "total": 2,
+  "next_cursor": "next",
+  "employees": [
+  {
+    "id": 5,
+    "name": "ruslan",
+    "surname": "ruslanov",
+    "phone": "89776677",
+    "company_id": 4,
+    "passport": {
+      "type": "0989",
+      "number": "0989"
+    },
+    "department": {
+      "name": "marketing",
+      "phone": "89"
+    }
+  },
+  {
+    "id": 6,
+    "name": "masha",
+    "surname": "naumova",
+    "phone": "12095",
+    "company_id": 4,
+    "passport": {
+      "type": "rf",
+      "number": "487484"
+    },
+    "department": {
+      "name": "marketing",
+      "phone": "89"
+    }
+  }
+]}`,
+		},
+		{
+			name:        "empty",
+			inputParams: &models.EmployeeListParams{DepartmentID: 2, Limit: 20},
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeListParams) {
+				m.EXPECT().GetListDepartmentCompanyEmployees(gomock.Any(), params).Return(&models.EmployeeList{Employees: []*models.Employee{}, Total: lo.ToPtr(int64(0))}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"employees":[],"total":0}`,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputParams)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/departments/{id}/employees", handler.GetDepartmentCompanyEmployees)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/departments/2/employees"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetCompanies(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, pagination *models.Pagination)
+	testTable := []struct {
+		name            string
+		query           string
+		inputPagination *models.Pagination
+		mockBehavior    mockBehavior
+		expectedCode    int
+		expectedBody    string
+	}{
+		{
+			name:            "ok: default pagination",
+			query:           "",
+			inputPagination: &models.Pagination{Limit: 20, Offset: 0},
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {
+				m.EXPECT().GetCompanies(gomock.Any(), pagination).Return([]*models.Company{
+					{ID: 1, Name: "first"},
+					{ID: 2, Name: "second"},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":1,"name":"first"},{"id":2,"name":"second"}]`,
+		},
+		{
+			name:            "ok: custom pagination",
+			query:           "?limit=1&offset=1",
+			inputPagination: &models.Pagination{Limit: 1, Offset: 1},
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {
+				m.EXPECT().GetCompanies(gomock.Any(), pagination).Return([]*models.Company{
+					{ID: 2, Name: "second"},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":2,"name":"second"}]`,
+		},
+		{
+			name:         "fail: limit too big",
+			query:        "?limit=1000",
+			mockBehavior: func(m *mockEmployee.MockUsecase, pagination *models.Pagination) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputPagination)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies", handler.GetCompanies)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/companies"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetCompany(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
+	testTable := []struct {
+		name         string
+		companyID    int32
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:      "ok",
+			companyID: 1,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().GetCompany(gomock.Any(), id).Return(&models.Company{ID: 1, Name: "first"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"id":1,"name":"first"}`,
+		},
+		{
+			name:      "not found",
+			companyID: 10,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().GetCompany(gomock.Any(), id).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.companyID)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}", handler.GetCompany)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/companies/%v", tt.companyID), nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_UpdateCompany(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, company *models.Company)
+	testTable := []struct {
+		name         string
+		inputBody    string
+		companyID    string
+		inputCompany *models.Company
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "ok",
+			inputBody:    `{"name":"renamed"}`,
+			companyID:    "1",
+			inputCompany: &models.Company{ID: 1, Name: "renamed"},
+			mockBehavior: func(m *mockEmployee.MockUsecase, company *models.Company) {
+				m.EXPECT().EditCompany(gomock.Any(), company).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"company updated"}`,
+		},
+		{
+			name:         "not found",
+			inputBody:    `{"name":"renamed"}`,
+			companyID:    "10",
+			inputCompany: &models.Company{ID: 10, Name: "renamed"},
+			mockBehavior: func(m *mockEmployee.MockUsecase, company *models.Company) {
+				m.EXPECT().EditCompany(gomock.Any(), company).Return(errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:         "null body",
+			inputBody:    `null`,
+			companyID:    "1",
+			mockBehavior: func(m *mockEmployee.MockUsecase, company *models.Company) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputCompany)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}", handler.UpdateCompany)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/companies/"+tt.companyID, bytes.NewBufferString(tt.inputBody))
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteCompany(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
+	testTable := []struct {
+		name         string
+		companyID    int32
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:      "ok",
+			companyID: 1,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteCompany(gomock.Any(), id).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"company deleted"}`,
+		},
+		{
+			name:      "not found",
+			companyID: 10,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteCompany(gomock.Any(), id).Return(errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:      "has employees",
+			companyID: 1,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteCompany(gomock.Any(), id).Return(fmt.Errorf("%w: company 1 has 3 employees", errs.ErrConflict))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeConflict, messages.Conflict),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.companyID)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}", handler.DeleteCompany)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/companies/%v", tt.companyID), nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetCompanyDepartments(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, companyID int32)
+	testTable := []struct {
+		name         string
+		companyID    int32
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:      "ok",
+			companyID: 1,
+			mockBehavior: func(m *mockEmployee.MockUsecase, companyID int32) {
+				m.EXPECT().GetListCompanyDepartments(gomock.Any(), companyID).Return([]*models.DepartmentInfo{
+					{ID: 1, Name: "marketing", Phone: "89", CompanyID: 1},
+					{ID: 2, Name: "dev", Phone: "1234", CompanyID: 1, ParentID: lo.ToPtr(int32(1))},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":1,"name":"marketing","phone":"89","company_id":1,"parent_department_id":null},
+{"id":2,"name":"dev","phone":"1234","company_id":1,"parent_department_id":1}]`,
+		},
+		{
+			name:      "empty",
+			companyID: 10,
+			mockBehavior: func(m *mockEmployee.MockUsecase, companyID int32) {
+				m.EXPECT().GetListCompanyDepartments(gomock.Any(), companyID).Return([]*models.DepartmentInfo{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "[]",
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.companyID)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}/departments", handler.GetCompanyDepartments)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/companies/%v/departments", tt.companyID), nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetDepartment(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
+	testTable := []struct {
+		name         string
+		departmentID int32
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "ok",
+			departmentID: 2,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().GetDepartment(gomock.Any(), id).Return(&models.DepartmentInfo{
+					ID: 2, Name: "dev", Phone: "1234", CompanyID: 1,
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"id":2,"name":"dev","phone":"1234","company_id":1,"parent_department_id":null}`,
+		},
+		{
+			name:         "not found",
+			departmentID: 20,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().GetDepartment(gomock.Any(), id).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.departmentID)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/departments/{id}", handler.GetDepartment)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/departments/%v", tt.departmentID), nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_GetOrgChart(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+	chart := &models.OrgChart{
+		ID:   1,
+		Name: "company",
+		Departments: []*models.OrgDepartment{{
+			ID:          2,
+			Name:        "dev",
+			Phone:       "1234",
+			Employees:   []*models.OrgEmployee{{ID: 5, Name: "katya", Surname: "ivanova", Phone: "+79161234567"}},
+			Departments: []*models.OrgDepartment{},
+		}},
+	}
+
+	testTable := []struct {
+		name                string
+		query               string
+		mockBehavior        mockBehavior
+		expectedCode        int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:  "json by default",
+			query: "",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetOrgChart(gomock.Any(), int32(1)).Return(chart, nil)
+			},
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody: `{"id":1,"name":"company","departments":[{"id":2,"name":"dev","phone":"1234",
+"employees":[{"id":5,"name":"katya","surname":"ivanova","phone":"+79161234567"}],"departments":[]}]}`,
+		},
+		{
+			name:  "mermaid",
+			query: "?format=mermaid",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetOrgChart(gomock.Any(), int32(1)).Return(chart, nil)
+			},
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody: `flowchart TD
+    company_1[["company"]]
+    department_2["dev"]
+    company_1 --> department_2
+    employee_5("katya ivanova")
+    department_2 --> employee_5
+`,
+		},
+		{
+			name:  "dot",
+			query: "?format=dot",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetOrgChart(gomock.Any(), int32(1)).Return(chart, nil)
+			},
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/vnd.graphviz; charset=utf-8",
+			expectedBody: `digraph orgchart {
+    rankdir=TB;
+    company_1 [label="company", shape=box3d];
+    department_2 [label="dev", shape=box];
+    company_1 -> department_2;
+    employee_5 [label="katya ivanova", shape=ellipse];
+    department_2 -> employee_5;
+}
+`,
+		},
+		{
+			name:         "unknown format",
+			query:        "?format=svg",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+		{
+			name:  "company not found",
+			query: "?format=dot",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetOrgChart(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody:        fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/companies/{id}/orgchart", handler.GetOrgChart)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/companies/1/orgchart"+tt.query, nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			}
+			if tt.expectedContentType == "" || tt.expectedContentType == "application/json" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Equal(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_GetDepartmentSubtree(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase)
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(2)).Return(&models.DepartmentTree{
+					DepartmentInfo: models.DepartmentInfo{ID: 2, Name: "dev", Phone: "1234", CompanyID: 1},
+					Children: []*models.DepartmentTree{{
+						DepartmentInfo: models.DepartmentInfo{ID: 3, Name: "backend", Phone: "5678", CompanyID: 1, ParentID: lo.ToPtr(int32(2))},
+						Children:       []*models.DepartmentTree{},
+					}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"id":2,"name":"dev","phone":"1234","company_id":1,"parent_department_id":null,"children":[
+{"id":3,"name":"backend","phone":"5678","company_id":1,"parent_department_id":2,"children":[]}
+]}`,
+		},
+		{
+			name: "not found",
+			mockBehavior: func(m *mockEmployee.MockUsecase) {
+				m.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(2)).Return(nil, errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/departments/{id}/subtree", handler.GetDepartmentSubtree)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/departments/2/subtree", nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_UpdateDepartment(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, department *models.CreateDepartment)
+	testTable := []struct {
+		name            string
+		inputBody       string
+		departmentID    string
+		inputDepartment *models.CreateDepartment
+		mockBehavior    mockBehavior
+		expectedCode    int
+		expectedBody    string
+	}{
+		{
+			name:         "ok",
+			inputBody:    `{"name":"sales","phone":"5555"}`,
+			departmentID: "2",
+			inputDepartment: &models.CreateDepartment{
+				ID:    2,
+				Name:  "sales",
+				Phone: "5555",
+			},
+			mockBehavior: func(m *mockEmployee.MockUsecase, department *models.CreateDepartment) {
+				m.EXPECT().EditDepartment(gomock.Any(), department).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"department updated"}`,
+		},
+		{
+			name:         "not found",
+			inputBody:    `{"name":"sales"}`,
+			departmentID: "20",
+			inputDepartment: &models.CreateDepartment{
+				ID:   20,
+				Name: "sales",
+			},
+			mockBehavior: func(m *mockEmployee.MockUsecase, department *models.CreateDepartment) {
+				m.EXPECT().EditDepartment(gomock.Any(), department).Return(errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:         "null body",
+			inputBody:    `null`,
+			departmentID: "2",
+			mockBehavior: func(m *mockEmployee.MockUsecase, department *models.CreateDepartment) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputDepartment)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/departments/{id}", handler.UpdateDepartment)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/departments/"+tt.departmentID, bytes.NewBufferString(tt.inputBody))
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteDepartment(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, id int32)
+	testTable := []struct {
+		name         string
+		departmentID int32
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "ok",
+			departmentID: 2,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteDepartment(gomock.Any(), id).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"msg":"department deleted"}`,
+		},
+		{
+			name:         "not found",
+			departmentID: 20,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteDepartment(gomock.Any(), id).Return(errs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeNotFound, messages.NotFound),
+		},
+		{
+			name:         "has employees",
+			departmentID: 2,
+			mockBehavior: func(m *mockEmployee.MockUsecase, id int32) {
+				m.EXPECT().DeleteDepartment(gomock.Any(), id).Return(fmt.Errorf("%w: department 2 has 3 employees", errs.ErrConflict))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v"}`, messages.CodeConflict, messages.Conflict),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.departmentID)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/departments/{id}", handler.DeleteDepartment)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/departments/%v", tt.departmentID), nil)
+
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_SearchEmployees(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockUsecase, params *models.EmployeeSearchParams)
+	testTable := []struct {
+		name         string
+		query        string
+		inputParams  *models.EmployeeSearchParams
+		mockBehavior mockBehavior
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "ok",
+			query:       "?q=ivan&company_id=4&limit=5",
+			inputParams: &models.EmployeeSearchParams{Query: "ivan", CompanyID: 4, Limit: 5},
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeSearchParams) {
+				m.EXPECT().SearchEmployees(gomock.Any(), params).Return([]*models.Employee{
+					{
+						ID:        7,
+						Name:      "ivan",
+						Surname:   "petrov",
+						Phone:     "18385",
+						CompanyID: 4,
+						Passport: models.Passport{
+							Type:   "rf",
+							Number: "407484",
+						},
+						Department: models.Department{
+							Name:  "dev",
+							Phone: "1234",
+						},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"id":7,"name":"ivan","surname":"petrov","phone":"18385","company_id":4,"passport":{"type":"rf","number":"407484"},"department":{"name":"dev","phone":"1234"}}]`,
+		},
+		{
+			name:        "short query",
+			query:       "?q=i",
+			inputParams: &models.EmployeeSearchParams{Query: "i", Limit: 20},
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeSearchParams) {
+				m.EXPECT().SearchEmployees(gomock.Any(), params).Return(nil, &errs.ValidationError{
+					Fields: []errs.FieldError{{Field: "q", Message: "must contain at least 2 characters"}},
+				})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: fmt.Sprintf(`{"code":"%v","msg":"%v","fields":[{"field":"q","message":"must contain at least 2 characters"}]}`, messages.CodeValidation, messages.ValidationFailed),
+		},
+		{
+			name:         "bad company id",
+			query:        "?q=ivan&company_id=abc",
+			mockBehavior: func(m *mockEmployee.MockUsecase, params *models.EmployeeSearchParams) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: fmt.Sprintf(`{"msg":"%v"}`, messages.BadRequest),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecaseEmployee := mockEmployee.NewMockUsecase(ctrl)
+			tt.mockBehavior(mockUsecaseEmployee, tt.inputParams)
+
+			handler := &Handler{
+				uc:  mockUsecaseEmployee,
+				log: logger.SetupLogger(),
+			}
+
+			router := mux.NewRouter()
+			router.HandleFunc("/employees/search", handler.SearchEmployees)
 
 			rec := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/departments/%v/employees", tt.departmentID), nil)
+			req := httptest.NewRequest(http.MethodGet, "/employees/search"+tt.query, nil)
 
 			router.ServeHTTP(rec, req)
 			assert.Equal(t, tt.expectedCode, rec.Code)
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"employees/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	maxImportSize      = 32 << 20
+	maxImportLineBytes = 1 << 20
+	maxImportRows      = 10000
+)
+
+var (
+	errTooManyRows   = fmt.Errorf("import is limited to %d rows", maxImportRows)
+	errMissingColumn = errors.New("missing column")
+	importCSVColumns = []string{"name", "surname", "phone", "department", "passport_type", "passport_number"}
+)
+
+// readImportCSV reads a CSV file with a header row. Broken lines are returned with ParseError set.
+func readImportCSV(r io.Reader) ([]*models.ImportEmployee, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range importCSVColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: %s", errMissingColumn, column)
+		}
+	}
+
+	var rows []*models.ImportEmployee
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, &models.ImportEmployee{Line: parseErr.Line, ParseError: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(header) {
+			rows = append(rows, &models.ImportEmployee{
+				Line:       line,
+				ParseError: fmt.Sprintf("expected %d fields, got %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		field := func(column string) string {
+			return strings.TrimSpace(record[columns[column]])
+		}
+		rows = append(rows, &models.ImportEmployee{
+			Line:       line,
+			Name:       field("name"),
+			Surname:    field("surname"),
+			Phone:      field("phone"),
+			Department: field("department"),
+			Passport: models.Passport{
+				Type:   field("passport_type"),
+				Number: field("passport_number"),
+			},
+		})
+	}
+	return rows, nil
+}
+
+// readImportNDJSON reads one JSON object per line, blank lines are skipped
+func readImportNDJSON(r io.Reader) ([]*models.ImportEmployee, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+
+	var rows []*models.ImportEmployee
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		row := &models.ImportEmployee{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(row); err != nil {
+			row = &models.ImportEmployee{ParseError: err.Error()}
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"employees/internal/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReadImportCSV(t *testing.T) {
+	input := `surname,name,phone,department,passport_type,passport_number
+ivanova,katya,+79161234567,dev,РФ,7878 898989
+petrov,ivan,+79161234568
+"broken,ivan,+79161234569,dev,РФ,7878 898988
+`
+	rows, err := readImportCSV(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, &models.ImportEmployee{
+		Line:       2,
+		Name:       "katya",
+		Surname:    "ivanova",
+		Phone:      "+79161234567",
+		Department: "dev",
+		Passport:   models.Passport{Type: "РФ", Number: "7878 898989"},
+	}, rows[0])
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, "expected 6 fields, got 3", rows[1].ParseError)
+	assert.Equal(t, 4, rows[2].Line)
+	assert.NotEmpty(t, rows[2].ParseError)
+}
+
+func TestReadImportCSV_MissingColumn(t *testing.T) {
+	_, err := readImportCSV(strings.NewReader("name,surname,phone\nkatya,ivanova,+79161234567\n"))
+	assert.ErrorIs(t, err, errMissingColumn)
+}
+
+func TestReadImportNDJSON(t *testing.T) {
+	input := `{"name":"katya","surname":"ivanova","phone":"+79161234567","department":"dev","passport":{"type":"РФ","number":"7878 898989"}}
+
+{"name":"ivan","unknown":1}
+not json
+`
+	rows, err := readImportNDJSON(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, &models.ImportEmployee{
+		Line:       1,
+		Name:       "katya",
+		Surname:    "ivanova",
+		Phone:      "+79161234567",
+		Department: "dev",
+		Passport:   models.Passport{Type: "РФ", Number: "7878 898989"},
+	}, rows[0])
+	assert.Equal(t, 3, rows[1].Line)
+	assert.NotEmpty(t, rows[1].ParseError)
+	assert.Equal(t, 4, rows[2].Line)
+	assert.NotEmpty(t, rows[2].ParseError)
+}
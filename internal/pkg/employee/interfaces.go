@@ -3,27 +3,95 @@ package employee
 import (
 	"context"
 	"employees/internal/models"
+	"time"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
 
 type Usecase interface {
 	CreateEmployee(ctx context.Context, employee *models.CreateEmployee) (int32, error)
-	DeleteEmployee(ctx context.Context, id int32) error
-	GetListCompanyEmployees(ctx context.Context, companyID int32) ([]*models.Employee, error)
-	GetListDepartmentCompanyEmployees(ctx context.Context, departmentID int32) ([]*models.Employee, error)
-	EditEmployee(ctx context.Context, employee *models.CreateEmployee) error
+	DeleteEmployee(ctx context.Context, id int32, version *int32) error
+	GetEmployee(ctx context.Context, id int32) (*models.Employee, error)
+	GetListCompanyEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error)
+	GetListDepartmentCompanyEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error)
+	SearchEmployees(ctx context.Context, params *models.EmployeeSearchParams) ([]*models.Employee, error)
+	EditEmployee(ctx context.Context, employee *models.UpdateEmployee) (int32, error)
+	GetArchivedEmployees(ctx context.Context, companyID int32, pagination *models.Pagination) ([]*models.Employee, error)
+	RestoreEmployee(ctx context.Context, id int32) error
+	PurgeArchivedEmployees(ctx context.Context, deletedBefore time.Time) (int64, error)
+	GetEmployeeHistory(ctx context.Context, id int32, pagination *models.Pagination) ([]*models.AuditRecord, error)
+	ImportEmployees(ctx context.Context, companyID int32, rows []*models.ImportEmployee, dryRun bool) (*models.ImportReport, error)
+	ExportEmployees(ctx context.Context, companyID int32, fn func(employee *models.Employee) error) error
+	BatchEmployees(ctx context.Context, request *models.BatchRequest) (*models.BatchResult, error)
+	TransferEmployee(ctx context.Context, transfer *models.Transfer) (*models.Assignment, error)
+	GetEmployeeAssignments(ctx context.Context, id int32) ([]*models.Assignment, error)
+	ApplyDueTransfers(ctx context.Context, now time.Time) (int, error)
+	GetReportingChain(ctx context.Context, id int32) ([]*models.Employee, error)
+	GetDirectReports(ctx context.Context, id int32) ([]*models.Employee, error)
+	GetEmployeeDocuments(ctx context.Context, employeeID int32) ([]*models.IdentityDocument, error)
+	GetEmployeeDocument(ctx context.Context, employeeID, id int32) (*models.IdentityDocument, error)
+	CreateEmployeeDocument(ctx context.Context, document *models.CreateIdentityDocument) (int32, error)
+	EditEmployeeDocument(ctx context.Context, document *models.UpdateIdentityDocument) error
+	DeleteEmployeeDocument(ctx context.Context, employeeID, id int32) error
 	CreateCompany(ctx context.Context, name string) (int32, error)
+	GetCompanies(ctx context.Context, pagination *models.Pagination) ([]*models.Company, error)
+	GetCompany(ctx context.Context, id int32) (*models.Company, error)
+	EditCompany(ctx context.Context, company *models.Company) error
+	DeleteCompany(ctx context.Context, id int32) error
+	GetOrgChart(ctx context.Context, companyID int32) (*models.OrgChart, error)
 	CreateDepartment(ctx context.Context, department *models.CreateDepartment) (int32, error)
+	GetDepartment(ctx context.Context, id int32) (*models.DepartmentInfo, error)
+	GetListCompanyDepartments(ctx context.Context, companyID int32) ([]*models.DepartmentInfo, error)
+	EditDepartment(ctx context.Context, department *models.CreateDepartment) error
+	DeleteDepartment(ctx context.Context, id int32) error
+	GetDepartmentSubtree(ctx context.Context, id int32) (*models.DepartmentTree, error)
+}
+
+// TxManager runs fn in one transaction, Repository calls made with the ctx passed to fn take part in it
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Repository interface {
 	CreateEmployee(ctx context.Context, employee *models.Employee) (int32, error)
-	DeleteEmployee(ctx context.Context, id int32) error
-	GetListCompanyEmployees(ctx context.Context, companyID int32) ([]*models.Employee, error)
-	GetListDepartmentEmployees(ctx context.Context, departmentID int32) ([]*models.Employee, error)
-	EditEmployee(ctx context.Context, employee *models.Employee) error
+	DeleteEmployee(ctx context.Context, id int32, version *int32) error
+	ListEmployees(ctx context.Context, params *models.EmployeeListParams, cursor *models.EmployeeCursor) ([]*models.Employee, error)
+	CountEmployees(ctx context.Context, params *models.EmployeeListParams) (int64, error)
+	SearchEmployees(ctx context.Context, params *models.EmployeeSearchParams) ([]*models.Employee, error)
+	EditEmployee(ctx context.Context, id int32, update func(employee *models.Employee) error) (int32, error)
+	ListArchivedEmployees(ctx context.Context, companyID int32, pagination *models.Pagination) ([]*models.Employee, error)
+	RestoreEmployee(ctx context.Context, id int32) error
+	PurgeEmployees(ctx context.Context, deletedBefore time.Time) (int64, error)
+	ListAuditRecords(ctx context.Context, entityType string, entityID int32, pagination *models.Pagination) ([]*models.AuditRecord, error)
+	FindEmployeesByIdentifiers(ctx context.Context, phones, passportNumbers []string) ([]*models.EmployeeIdentifiers, error)
+	ImportEmployees(ctx context.Context, employees []*models.Employee) ([]int32, error)
+	ExportEmployees(ctx context.Context, companyID int32, fn func(employee *models.Employee) error) error
+	ApplyEmployeeChanges(ctx context.Context, changes []*models.EmployeeChange, atomic bool) ([]models.EmployeeChangeResult, error)
+	CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error)
+	ApplyAssignment(ctx context.Context, id int64) error
+	CancelAssignment(ctx context.Context, id int64) error
+	ListDueAssignments(ctx context.Context, date time.Time, limit int32) ([]*models.Assignment, error)
+	ListEmployeeAssignments(ctx context.Context, employeeID int32) ([]*models.Assignment, error)
+	GetReportingChain(ctx context.Context, id int32) ([]*models.Employee, error)
+	ListDirectReports(ctx context.Context, managerID int32) ([]*models.Employee, error)
+	ListIdentityDocuments(ctx context.Context, employeeID int32) ([]*models.IdentityDocument, error)
+	GetIdentityDocument(ctx context.Context, employeeID, id int32) (*models.IdentityDocument, error)
+	CreateIdentityDocument(ctx context.Context, document *models.IdentityDocument) (int32, error)
+	EditIdentityDocument(ctx context.Context, employeeID, id int32, update func(document *models.IdentityDocument) error) error
+	DeleteIdentityDocument(ctx context.Context, employeeID, id int32) error
+	EncryptIdentityDocuments(ctx context.Context, batchSize int32) (int, error)
+	RotateIdentityDocuments(ctx context.Context, batchSize int32, progress func(rotation *models.KeyRotation)) error
 	CreateCompany(ctx context.Context, name string) (int32, error)
+	GetCompanies(ctx context.Context, pagination *models.Pagination) ([]*models.Company, error)
+	GetCompanyByID(ctx context.Context, id int32) (*models.Company, error)
+	EditCompany(ctx context.Context, company *models.Company) error
+	DeleteCompany(ctx context.Context, id int32) error
 	CreateDepartment(ctx context.Context, department *models.Department) (int32, error)
+	GetDepartmentByID(ctx context.Context, id int32) (*models.Department, error)
+	GetCompanyDepartments(ctx context.Context, companyID int32) ([]*models.Department, error)
+	EditDepartment(ctx context.Context, department *models.Department) error
+	DeleteDepartment(ctx context.Context, id int32) error
+	GetDepartmentSubtree(ctx context.Context, id int32) ([]*models.Department, error)
 	GetEmployeeByID(ctx context.Context, id int32) (*models.Employee, error)
+	GetEmployeeScope(ctx context.Context, id int32) (*models.EmployeeScope, error)
 }
@@ -13,6 +13,7 @@ import (
 	context "context"
 	models "employees/internal/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,36 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
+// ApplyDueTransfers mocks base method.
+func (m *MockUsecase) ApplyDueTransfers(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDueTransfers", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDueTransfers indicates an expected call of ApplyDueTransfers.
+func (mr *MockUsecaseMockRecorder) ApplyDueTransfers(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDueTransfers", reflect.TypeOf((*MockUsecase)(nil).ApplyDueTransfers), ctx, now)
+}
+
+// BatchEmployees mocks base method.
+func (m *MockUsecase) BatchEmployees(ctx context.Context, request *models.BatchRequest) (*models.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchEmployees", ctx, request)
+	ret0, _ := ret[0].(*models.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchEmployees indicates an expected call of BatchEmployees.
+func (mr *MockUsecaseMockRecorder) BatchEmployees(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchEmployees", reflect.TypeOf((*MockUsecase)(nil).BatchEmployees), ctx, request)
+}
+
 // CreateCompany mocks base method.
 func (m *MockUsecase) CreateCompany(ctx context.Context, name string) (int32, error) {
 	m.ctrl.T.Helper()
@@ -86,202 +117,1123 @@ func (mr *MockUsecaseMockRecorder) CreateEmployee(ctx, employee any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployee", reflect.TypeOf((*MockUsecase)(nil).CreateEmployee), ctx, employee)
 }
 
+// CreateEmployeeDocument mocks base method.
+func (m *MockUsecase) CreateEmployeeDocument(ctx context.Context, document *models.CreateIdentityDocument) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmployeeDocument", ctx, document)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmployeeDocument indicates an expected call of CreateEmployeeDocument.
+func (mr *MockUsecaseMockRecorder) CreateEmployeeDocument(ctx, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployeeDocument", reflect.TypeOf((*MockUsecase)(nil).CreateEmployeeDocument), ctx, document)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockUsecase) DeleteCompany(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockUsecaseMockRecorder) DeleteCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockUsecase)(nil).DeleteCompany), ctx, id)
+}
+
+// DeleteDepartment mocks base method.
+func (m *MockUsecase) DeleteDepartment(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockUsecaseMockRecorder) DeleteDepartment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*MockUsecase)(nil).DeleteDepartment), ctx, id)
+}
+
 // DeleteEmployee mocks base method.
-func (m *MockUsecase) DeleteEmployee(ctx context.Context, id int32) error {
+func (m *MockUsecase) DeleteEmployee(ctx context.Context, id int32, version *int32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEmployee", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteEmployee", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteEmployee indicates an expected call of DeleteEmployee.
-func (mr *MockUsecaseMockRecorder) DeleteEmployee(ctx, id any) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) DeleteEmployee(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockUsecase)(nil).DeleteEmployee), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockUsecase)(nil).DeleteEmployee), ctx, id, version)
 }
 
-// EditEmployee mocks base method.
-func (m *MockUsecase) EditEmployee(ctx context.Context, employee *models.CreateEmployee) error {
+// DeleteEmployeeDocument mocks base method.
+func (m *MockUsecase) DeleteEmployeeDocument(ctx context.Context, employeeID, id int32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditEmployee", ctx, employee)
+	ret := m.ctrl.Call(m, "DeleteEmployeeDocument", ctx, employeeID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmployeeDocument indicates an expected call of DeleteEmployeeDocument.
+func (mr *MockUsecaseMockRecorder) DeleteEmployeeDocument(ctx, employeeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployeeDocument", reflect.TypeOf((*MockUsecase)(nil).DeleteEmployeeDocument), ctx, employeeID, id)
+}
+
+// EditCompany mocks base method.
+func (m *MockUsecase) EditCompany(ctx context.Context, company *models.Company) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditCompany", ctx, company)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditCompany indicates an expected call of EditCompany.
+func (mr *MockUsecaseMockRecorder) EditCompany(ctx, company any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditCompany", reflect.TypeOf((*MockUsecase)(nil).EditCompany), ctx, company)
+}
+
+// EditDepartment mocks base method.
+func (m *MockUsecase) EditDepartment(ctx context.Context, department *models.CreateDepartment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditDepartment", ctx, department)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// EditDepartment indicates an expected call of EditDepartment.
+func (mr *MockUsecaseMockRecorder) EditDepartment(ctx, department any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditDepartment", reflect.TypeOf((*MockUsecase)(nil).EditDepartment), ctx, department)
+}
+
+// EditEmployee mocks base method.
+func (m *MockUsecase) EditEmployee(ctx context.Context, employee *models.UpdateEmployee) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditEmployee", ctx, employee)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // EditEmployee indicates an expected call of EditEmployee.
 func (mr *MockUsecaseMockRecorder) EditEmployee(ctx, employee any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditEmployee", reflect.TypeOf((*MockUsecase)(nil).EditEmployee), ctx, employee)
 }
 
-// GetListCompanyEmployees mocks base method.
-func (m *MockUsecase) GetListCompanyEmployees(ctx context.Context, companyID int32) ([]*models.Employee, error) {
+// EditEmployeeDocument mocks base method.
+func (m *MockUsecase) EditEmployeeDocument(ctx context.Context, document *models.UpdateIdentityDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditEmployeeDocument", ctx, document)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditEmployeeDocument indicates an expected call of EditEmployeeDocument.
+func (mr *MockUsecaseMockRecorder) EditEmployeeDocument(ctx, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditEmployeeDocument", reflect.TypeOf((*MockUsecase)(nil).EditEmployeeDocument), ctx, document)
+}
+
+// ExportEmployees mocks base method.
+func (m *MockUsecase) ExportEmployees(ctx context.Context, companyID int32, fn func(*models.Employee) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEmployees", ctx, companyID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEmployees indicates an expected call of ExportEmployees.
+func (mr *MockUsecaseMockRecorder) ExportEmployees(ctx, companyID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEmployees", reflect.TypeOf((*MockUsecase)(nil).ExportEmployees), ctx, companyID, fn)
+}
+
+// GetArchivedEmployees mocks base method.
+func (m *MockUsecase) GetArchivedEmployees(ctx context.Context, companyID int32, pagination *models.Pagination) ([]*models.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListCompanyEmployees", ctx, companyID)
+	ret := m.ctrl.Call(m, "GetArchivedEmployees", ctx, companyID, pagination)
 	ret0, _ := ret[0].([]*models.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetListCompanyEmployees indicates an expected call of GetListCompanyEmployees.
-func (mr *MockUsecaseMockRecorder) GetListCompanyEmployees(ctx, companyID any) *gomock.Call {
+// GetArchivedEmployees indicates an expected call of GetArchivedEmployees.
+func (mr *MockUsecaseMockRecorder) GetArchivedEmployees(ctx, companyID, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListCompanyEmployees", reflect.TypeOf((*MockUsecase)(nil).GetListCompanyEmployees), ctx, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedEmployees", reflect.TypeOf((*MockUsecase)(nil).GetArchivedEmployees), ctx, companyID, pagination)
 }
 
-// GetListDepartmentCompanyEmployees mocks base method.
-func (m *MockUsecase) GetListDepartmentCompanyEmployees(ctx context.Context, departmentID int32) ([]*models.Employee, error) {
+// GetCompanies mocks base method.
+func (m *MockUsecase) GetCompanies(ctx context.Context, pagination *models.Pagination) ([]*models.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanies", ctx, pagination)
+	ret0, _ := ret[0].([]*models.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanies indicates an expected call of GetCompanies.
+func (mr *MockUsecaseMockRecorder) GetCompanies(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanies", reflect.TypeOf((*MockUsecase)(nil).GetCompanies), ctx, pagination)
+}
+
+// GetCompany mocks base method.
+func (m *MockUsecase) GetCompany(ctx context.Context, id int32) (*models.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompany", ctx, id)
+	ret0, _ := ret[0].(*models.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompany indicates an expected call of GetCompany.
+func (mr *MockUsecaseMockRecorder) GetCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockUsecase)(nil).GetCompany), ctx, id)
+}
+
+// GetDepartment mocks base method.
+func (m *MockUsecase) GetDepartment(ctx context.Context, id int32) (*models.DepartmentInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartment", ctx, id)
+	ret0, _ := ret[0].(*models.DepartmentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartment indicates an expected call of GetDepartment.
+func (mr *MockUsecaseMockRecorder) GetDepartment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartment", reflect.TypeOf((*MockUsecase)(nil).GetDepartment), ctx, id)
+}
+
+// GetDepartmentSubtree mocks base method.
+func (m *MockUsecase) GetDepartmentSubtree(ctx context.Context, id int32) (*models.DepartmentTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentSubtree", ctx, id)
+	ret0, _ := ret[0].(*models.DepartmentTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentSubtree indicates an expected call of GetDepartmentSubtree.
+func (mr *MockUsecaseMockRecorder) GetDepartmentSubtree(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentSubtree", reflect.TypeOf((*MockUsecase)(nil).GetDepartmentSubtree), ctx, id)
+}
+
+// GetDirectReports mocks base method.
+func (m *MockUsecase) GetDirectReports(ctx context.Context, id int32) ([]*models.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListDepartmentCompanyEmployees", ctx, departmentID)
+	ret := m.ctrl.Call(m, "GetDirectReports", ctx, id)
 	ret0, _ := ret[0].([]*models.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetListDepartmentCompanyEmployees indicates an expected call of GetListDepartmentCompanyEmployees.
-func (mr *MockUsecaseMockRecorder) GetListDepartmentCompanyEmployees(ctx, departmentID any) *gomock.Call {
+// GetDirectReports indicates an expected call of GetDirectReports.
+func (mr *MockUsecaseMockRecorder) GetDirectReports(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDepartmentCompanyEmployees", reflect.TypeOf((*MockUsecase)(nil).GetListDepartmentCompanyEmployees), ctx, departmentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectReports", reflect.TypeOf((*MockUsecase)(nil).GetDirectReports), ctx, id)
 }
 
-// MockRepository is a mock of Repository interface.
-type MockRepository struct {
-	ctrl     *gomock.Controller
-	recorder *MockRepositoryMockRecorder
-	isgomock struct{}
+// GetEmployee mocks base method.
+func (m *MockUsecase) GetEmployee(ctx context.Context, id int32) (*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployee", ctx, id)
+	ret0, _ := ret[0].(*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockRepositoryMockRecorder is the mock recorder for MockRepository.
-type MockRepositoryMockRecorder struct {
-	mock *MockRepository
+// GetEmployee indicates an expected call of GetEmployee.
+func (mr *MockUsecaseMockRecorder) GetEmployee(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployee", reflect.TypeOf((*MockUsecase)(nil).GetEmployee), ctx, id)
 }
 
-// NewMockRepository creates a new mock instance.
-func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
-	mock := &MockRepository{ctrl: ctrl}
-	mock.recorder = &MockRepositoryMockRecorder{mock}
-	return mock
+// GetEmployeeAssignments mocks base method.
+func (m *MockUsecase) GetEmployeeAssignments(ctx context.Context, id int32) ([]*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeAssignments", ctx, id)
+	ret0, _ := ret[0].([]*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
-	return m.recorder
+// GetEmployeeAssignments indicates an expected call of GetEmployeeAssignments.
+func (mr *MockUsecaseMockRecorder) GetEmployeeAssignments(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeAssignments", reflect.TypeOf((*MockUsecase)(nil).GetEmployeeAssignments), ctx, id)
 }
 
-// CreateCompany mocks base method.
-func (m *MockRepository) CreateCompany(ctx context.Context, name string) (int32, error) {
+// GetEmployeeDocument mocks base method.
+func (m *MockUsecase) GetEmployeeDocument(ctx context.Context, employeeID, id int32) (*models.IdentityDocument, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCompany", ctx, name)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "GetEmployeeDocument", ctx, employeeID, id)
+	ret0, _ := ret[0].(*models.IdentityDocument)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCompany indicates an expected call of CreateCompany.
-func (mr *MockRepositoryMockRecorder) CreateCompany(ctx, name any) *gomock.Call {
+// GetEmployeeDocument indicates an expected call of GetEmployeeDocument.
+func (mr *MockUsecaseMockRecorder) GetEmployeeDocument(ctx, employeeID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockRepository)(nil).CreateCompany), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeDocument", reflect.TypeOf((*MockUsecase)(nil).GetEmployeeDocument), ctx, employeeID, id)
 }
 
-// CreateDepartment mocks base method.
-func (m *MockRepository) CreateDepartment(ctx context.Context, department *models.Department) (int32, error) {
+// GetEmployeeDocuments mocks base method.
+func (m *MockUsecase) GetEmployeeDocuments(ctx context.Context, employeeID int32) ([]*models.IdentityDocument, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDepartment", ctx, department)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "GetEmployeeDocuments", ctx, employeeID)
+	ret0, _ := ret[0].([]*models.IdentityDocument)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateDepartment indicates an expected call of CreateDepartment.
-func (mr *MockRepositoryMockRecorder) CreateDepartment(ctx, department any) *gomock.Call {
+// GetEmployeeDocuments indicates an expected call of GetEmployeeDocuments.
+func (mr *MockUsecaseMockRecorder) GetEmployeeDocuments(ctx, employeeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockRepository)(nil).CreateDepartment), ctx, department)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeDocuments", reflect.TypeOf((*MockUsecase)(nil).GetEmployeeDocuments), ctx, employeeID)
 }
 
-// CreateEmployee mocks base method.
-func (m *MockRepository) CreateEmployee(ctx context.Context, employee *models.Employee) (int32, error) {
+// GetEmployeeHistory mocks base method.
+func (m *MockUsecase) GetEmployeeHistory(ctx context.Context, id int32, pagination *models.Pagination) ([]*models.AuditRecord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEmployee", ctx, employee)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "GetEmployeeHistory", ctx, id, pagination)
+	ret0, _ := ret[0].([]*models.AuditRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEmployee indicates an expected call of CreateEmployee.
-func (mr *MockRepositoryMockRecorder) CreateEmployee(ctx, employee any) *gomock.Call {
+// GetEmployeeHistory indicates an expected call of GetEmployeeHistory.
+func (mr *MockUsecaseMockRecorder) GetEmployeeHistory(ctx, id, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployee", reflect.TypeOf((*MockRepository)(nil).CreateEmployee), ctx, employee)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeHistory", reflect.TypeOf((*MockUsecase)(nil).GetEmployeeHistory), ctx, id, pagination)
 }
 
-// DeleteEmployee mocks base method.
-func (m *MockRepository) DeleteEmployee(ctx context.Context, id int32) error {
+// GetListCompanyDepartments mocks base method.
+func (m *MockUsecase) GetListCompanyDepartments(ctx context.Context, companyID int32) ([]*models.DepartmentInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEmployee", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetListCompanyDepartments", ctx, companyID)
+	ret0, _ := ret[0].([]*models.DepartmentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEmployee indicates an expected call of DeleteEmployee.
-func (mr *MockRepositoryMockRecorder) DeleteEmployee(ctx, id any) *gomock.Call {
+// GetListCompanyDepartments indicates an expected call of GetListCompanyDepartments.
+func (mr *MockUsecaseMockRecorder) GetListCompanyDepartments(ctx, companyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockRepository)(nil).DeleteEmployee), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListCompanyDepartments", reflect.TypeOf((*MockUsecase)(nil).GetListCompanyDepartments), ctx, companyID)
 }
 
-// EditEmployee mocks base method.
-func (m *MockRepository) EditEmployee(ctx context.Context, employee *models.Employee) error {
+// GetListCompanyEmployees mocks base method.
+func (m *MockUsecase) GetListCompanyEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditEmployee", ctx, employee)
+	ret := m.ctrl.Call(m, "GetListCompanyEmployees", ctx, params)
+	ret0, _ := ret[0].(*models.EmployeeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListCompanyEmployees indicates an expected call of GetListCompanyEmployees.
+func (mr *MockUsecaseMockRecorder) GetListCompanyEmployees(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListCompanyEmployees", reflect.TypeOf((*MockUsecase)(nil).GetListCompanyEmployees), ctx, params)
+}
+
+// GetListDepartmentCompanyEmployees mocks base method.
+func (m *MockUsecase) GetListDepartmentCompanyEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListDepartmentCompanyEmployees", ctx, params)
+	ret0, _ := ret[0].(*models.EmployeeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListDepartmentCompanyEmployees indicates an expected call of GetListDepartmentCompanyEmployees.
+func (mr *MockUsecaseMockRecorder) GetListDepartmentCompanyEmployees(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDepartmentCompanyEmployees", reflect.TypeOf((*MockUsecase)(nil).GetListDepartmentCompanyEmployees), ctx, params)
+}
+
+// GetOrgChart mocks base method.
+func (m *MockUsecase) GetOrgChart(ctx context.Context, companyID int32) (*models.OrgChart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgChart", ctx, companyID)
+	ret0, _ := ret[0].(*models.OrgChart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgChart indicates an expected call of GetOrgChart.
+func (mr *MockUsecaseMockRecorder) GetOrgChart(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgChart", reflect.TypeOf((*MockUsecase)(nil).GetOrgChart), ctx, companyID)
+}
+
+// GetReportingChain mocks base method.
+func (m *MockUsecase) GetReportingChain(ctx context.Context, id int32) ([]*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportingChain", ctx, id)
+	ret0, _ := ret[0].([]*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportingChain indicates an expected call of GetReportingChain.
+func (mr *MockUsecaseMockRecorder) GetReportingChain(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportingChain", reflect.TypeOf((*MockUsecase)(nil).GetReportingChain), ctx, id)
+}
+
+// ImportEmployees mocks base method.
+func (m *MockUsecase) ImportEmployees(ctx context.Context, companyID int32, rows []*models.ImportEmployee, dryRun bool) (*models.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEmployees", ctx, companyID, rows, dryRun)
+	ret0, _ := ret[0].(*models.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportEmployees indicates an expected call of ImportEmployees.
+func (mr *MockUsecaseMockRecorder) ImportEmployees(ctx, companyID, rows, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEmployees", reflect.TypeOf((*MockUsecase)(nil).ImportEmployees), ctx, companyID, rows, dryRun)
+}
+
+// PurgeArchivedEmployees mocks base method.
+func (m *MockUsecase) PurgeArchivedEmployees(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeArchivedEmployees", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeArchivedEmployees indicates an expected call of PurgeArchivedEmployees.
+func (mr *MockUsecaseMockRecorder) PurgeArchivedEmployees(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeArchivedEmployees", reflect.TypeOf((*MockUsecase)(nil).PurgeArchivedEmployees), ctx, deletedBefore)
+}
+
+// RestoreEmployee mocks base method.
+func (m *MockUsecase) RestoreEmployee(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreEmployee", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EditEmployee indicates an expected call of EditEmployee.
-func (mr *MockRepositoryMockRecorder) EditEmployee(ctx, employee any) *gomock.Call {
+// RestoreEmployee indicates an expected call of RestoreEmployee.
+func (mr *MockUsecaseMockRecorder) RestoreEmployee(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditEmployee", reflect.TypeOf((*MockRepository)(nil).EditEmployee), ctx, employee)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEmployee", reflect.TypeOf((*MockUsecase)(nil).RestoreEmployee), ctx, id)
 }
 
-// GetEmployeeByID mocks base method.
-func (m *MockRepository) GetEmployeeByID(ctx context.Context, id int32) (*models.Employee, error) {
+// SearchEmployees mocks base method.
+func (m *MockUsecase) SearchEmployees(ctx context.Context, params *models.EmployeeSearchParams) ([]*models.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployeeByID", ctx, id)
-	ret0, _ := ret[0].(*models.Employee)
+	ret := m.ctrl.Call(m, "SearchEmployees", ctx, params)
+	ret0, _ := ret[0].([]*models.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployeeByID indicates an expected call of GetEmployeeByID.
-func (mr *MockRepositoryMockRecorder) GetEmployeeByID(ctx, id any) *gomock.Call {
+// SearchEmployees indicates an expected call of SearchEmployees.
+func (mr *MockUsecaseMockRecorder) SearchEmployees(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByID", reflect.TypeOf((*MockRepository)(nil).GetEmployeeByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEmployees", reflect.TypeOf((*MockUsecase)(nil).SearchEmployees), ctx, params)
 }
 
-// GetListCompanyEmployees mocks base method.
-func (m *MockRepository) GetListCompanyEmployees(ctx context.Context, companyID int32) ([]*models.Employee, error) {
+// TransferEmployee mocks base method.
+func (m *MockUsecase) TransferEmployee(ctx context.Context, transfer *models.Transfer) (*models.Assignment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListCompanyEmployees", ctx, companyID)
-	ret0, _ := ret[0].([]*models.Employee)
+	ret := m.ctrl.Call(m, "TransferEmployee", ctx, transfer)
+	ret0, _ := ret[0].(*models.Assignment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetListCompanyEmployees indicates an expected call of GetListCompanyEmployees.
-func (mr *MockRepositoryMockRecorder) GetListCompanyEmployees(ctx, companyID any) *gomock.Call {
+// TransferEmployee indicates an expected call of TransferEmployee.
+func (mr *MockUsecaseMockRecorder) TransferEmployee(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferEmployee", reflect.TypeOf((*MockUsecase)(nil).TransferEmployee), ctx, transfer)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyAssignment mocks base method.
+func (m *MockRepository) ApplyAssignment(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyAssignment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyAssignment indicates an expected call of ApplyAssignment.
+func (mr *MockRepositoryMockRecorder) ApplyAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAssignment", reflect.TypeOf((*MockRepository)(nil).ApplyAssignment), ctx, id)
+}
+
+// ApplyEmployeeChanges mocks base method.
+func (m *MockRepository) ApplyEmployeeChanges(ctx context.Context, changes []*models.EmployeeChange, atomic bool) ([]models.EmployeeChangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyEmployeeChanges", ctx, changes, atomic)
+	ret0, _ := ret[0].([]models.EmployeeChangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyEmployeeChanges indicates an expected call of ApplyEmployeeChanges.
+func (mr *MockRepositoryMockRecorder) ApplyEmployeeChanges(ctx, changes, atomic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyEmployeeChanges", reflect.TypeOf((*MockRepository)(nil).ApplyEmployeeChanges), ctx, changes, atomic)
+}
+
+// CancelAssignment mocks base method.
+func (m *MockRepository) CancelAssignment(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAssignment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelAssignment indicates an expected call of CancelAssignment.
+func (mr *MockRepositoryMockRecorder) CancelAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAssignment", reflect.TypeOf((*MockRepository)(nil).CancelAssignment), ctx, id)
+}
+
+// CountEmployees mocks base method.
+func (m *MockRepository) CountEmployees(ctx context.Context, params *models.EmployeeListParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEmployees", ctx, params)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEmployees indicates an expected call of CountEmployees.
+func (mr *MockRepositoryMockRecorder) CountEmployees(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEmployees", reflect.TypeOf((*MockRepository)(nil).CountEmployees), ctx, params)
+}
+
+// CreateAssignment mocks base method.
+func (m *MockRepository) CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssignment", ctx, assignment)
+	ret0, _ := ret[0].(*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssignment indicates an expected call of CreateAssignment.
+func (mr *MockRepositoryMockRecorder) CreateAssignment(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignment", reflect.TypeOf((*MockRepository)(nil).CreateAssignment), ctx, assignment)
+}
+
+// CreateCompany mocks base method.
+func (m *MockRepository) CreateCompany(ctx context.Context, name string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompany", ctx, name)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompany indicates an expected call of CreateCompany.
+func (mr *MockRepositoryMockRecorder) CreateCompany(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockRepository)(nil).CreateCompany), ctx, name)
+}
+
+// CreateDepartment mocks base method.
+func (m *MockRepository) CreateDepartment(ctx context.Context, department *models.Department) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepartment", ctx, department)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDepartment indicates an expected call of CreateDepartment.
+func (mr *MockRepositoryMockRecorder) CreateDepartment(ctx, department any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockRepository)(nil).CreateDepartment), ctx, department)
+}
+
+// CreateEmployee mocks base method.
+func (m *MockRepository) CreateEmployee(ctx context.Context, employee *models.Employee) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmployee", ctx, employee)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmployee indicates an expected call of CreateEmployee.
+func (mr *MockRepositoryMockRecorder) CreateEmployee(ctx, employee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployee", reflect.TypeOf((*MockRepository)(nil).CreateEmployee), ctx, employee)
+}
+
+// CreateIdentityDocument mocks base method.
+func (m *MockRepository) CreateIdentityDocument(ctx context.Context, document *models.IdentityDocument) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentityDocument", ctx, document)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdentityDocument indicates an expected call of CreateIdentityDocument.
+func (mr *MockRepositoryMockRecorder) CreateIdentityDocument(ctx, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentityDocument", reflect.TypeOf((*MockRepository)(nil).CreateIdentityDocument), ctx, document)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockRepository) DeleteCompany(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockRepositoryMockRecorder) DeleteCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockRepository)(nil).DeleteCompany), ctx, id)
+}
+
+// DeleteDepartment mocks base method.
+func (m *MockRepository) DeleteDepartment(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockRepositoryMockRecorder) DeleteDepartment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*MockRepository)(nil).DeleteDepartment), ctx, id)
+}
+
+// DeleteEmployee mocks base method.
+func (m *MockRepository) DeleteEmployee(ctx context.Context, id int32, version *int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmployee", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmployee indicates an expected call of DeleteEmployee.
+func (mr *MockRepositoryMockRecorder) DeleteEmployee(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockRepository)(nil).DeleteEmployee), ctx, id, version)
+}
+
+// DeleteIdentityDocument mocks base method.
+func (m *MockRepository) DeleteIdentityDocument(ctx context.Context, employeeID, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdentityDocument", ctx, employeeID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdentityDocument indicates an expected call of DeleteIdentityDocument.
+func (mr *MockRepositoryMockRecorder) DeleteIdentityDocument(ctx, employeeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentityDocument", reflect.TypeOf((*MockRepository)(nil).DeleteIdentityDocument), ctx, employeeID, id)
+}
+
+// EditCompany mocks base method.
+func (m *MockRepository) EditCompany(ctx context.Context, company *models.Company) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditCompany", ctx, company)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditCompany indicates an expected call of EditCompany.
+func (mr *MockRepositoryMockRecorder) EditCompany(ctx, company any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditCompany", reflect.TypeOf((*MockRepository)(nil).EditCompany), ctx, company)
+}
+
+// EditDepartment mocks base method.
+func (m *MockRepository) EditDepartment(ctx context.Context, department *models.Department) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditDepartment", ctx, department)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditDepartment indicates an expected call of EditDepartment.
+func (mr *MockRepositoryMockRecorder) EditDepartment(ctx, department any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditDepartment", reflect.TypeOf((*MockRepository)(nil).EditDepartment), ctx, department)
+}
+
+// EditEmployee mocks base method.
+func (m *MockRepository) EditEmployee(ctx context.Context, id int32, update func(*models.Employee) error) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditEmployee", ctx, id, update)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditEmployee indicates an expected call of EditEmployee.
+func (mr *MockRepositoryMockRecorder) EditEmployee(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditEmployee", reflect.TypeOf((*MockRepository)(nil).EditEmployee), ctx, id, update)
+}
+
+// EditIdentityDocument mocks base method.
+func (m *MockRepository) EditIdentityDocument(ctx context.Context, employeeID, id int32, update func(*models.IdentityDocument) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditIdentityDocument", ctx, employeeID, id, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditIdentityDocument indicates an expected call of EditIdentityDocument.
+func (mr *MockRepositoryMockRecorder) EditIdentityDocument(ctx, employeeID, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditIdentityDocument", reflect.TypeOf((*MockRepository)(nil).EditIdentityDocument), ctx, employeeID, id, update)
+}
+
+// EncryptIdentityDocuments mocks base method.
+func (m *MockRepository) EncryptIdentityDocuments(ctx context.Context, batchSize int32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptIdentityDocuments", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EncryptIdentityDocuments indicates an expected call of EncryptIdentityDocuments.
+func (mr *MockRepositoryMockRecorder) EncryptIdentityDocuments(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptIdentityDocuments", reflect.TypeOf((*MockRepository)(nil).EncryptIdentityDocuments), ctx, batchSize)
+}
+
+// ExportEmployees mocks base method.
+func (m *MockRepository) ExportEmployees(ctx context.Context, companyID int32, fn func(*models.Employee) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEmployees", ctx, companyID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEmployees indicates an expected call of ExportEmployees.
+func (mr *MockRepositoryMockRecorder) ExportEmployees(ctx, companyID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEmployees", reflect.TypeOf((*MockRepository)(nil).ExportEmployees), ctx, companyID, fn)
+}
+
+// FindEmployeesByIdentifiers mocks base method.
+func (m *MockRepository) FindEmployeesByIdentifiers(ctx context.Context, phones, passportNumbers []string) ([]*models.EmployeeIdentifiers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmployeesByIdentifiers", ctx, phones, passportNumbers)
+	ret0, _ := ret[0].([]*models.EmployeeIdentifiers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmployeesByIdentifiers indicates an expected call of FindEmployeesByIdentifiers.
+func (mr *MockRepositoryMockRecorder) FindEmployeesByIdentifiers(ctx, phones, passportNumbers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmployeesByIdentifiers", reflect.TypeOf((*MockRepository)(nil).FindEmployeesByIdentifiers), ctx, phones, passportNumbers)
+}
+
+// GetCompanies mocks base method.
+func (m *MockRepository) GetCompanies(ctx context.Context, pagination *models.Pagination) ([]*models.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanies", ctx, pagination)
+	ret0, _ := ret[0].([]*models.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanies indicates an expected call of GetCompanies.
+func (mr *MockRepositoryMockRecorder) GetCompanies(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanies", reflect.TypeOf((*MockRepository)(nil).GetCompanies), ctx, pagination)
+}
+
+// GetCompanyByID mocks base method.
+func (m *MockRepository) GetCompanyByID(ctx context.Context, id int32) (*models.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyByID", ctx, id)
+	ret0, _ := ret[0].(*models.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyByID indicates an expected call of GetCompanyByID.
+func (mr *MockRepositoryMockRecorder) GetCompanyByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyByID", reflect.TypeOf((*MockRepository)(nil).GetCompanyByID), ctx, id)
+}
+
+// GetCompanyDepartments mocks base method.
+func (m *MockRepository) GetCompanyDepartments(ctx context.Context, companyID int32) ([]*models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyDepartments", ctx, companyID)
+	ret0, _ := ret[0].([]*models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyDepartments indicates an expected call of GetCompanyDepartments.
+func (mr *MockRepositoryMockRecorder) GetCompanyDepartments(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyDepartments", reflect.TypeOf((*MockRepository)(nil).GetCompanyDepartments), ctx, companyID)
+}
+
+// GetDepartmentByID mocks base method.
+func (m *MockRepository) GetDepartmentByID(ctx context.Context, id int32) (*models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentByID", ctx, id)
+	ret0, _ := ret[0].(*models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentByID indicates an expected call of GetDepartmentByID.
+func (mr *MockRepositoryMockRecorder) GetDepartmentByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentByID", reflect.TypeOf((*MockRepository)(nil).GetDepartmentByID), ctx, id)
+}
+
+// GetDepartmentSubtree mocks base method.
+func (m *MockRepository) GetDepartmentSubtree(ctx context.Context, id int32) ([]*models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentSubtree", ctx, id)
+	ret0, _ := ret[0].([]*models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentSubtree indicates an expected call of GetDepartmentSubtree.
+func (mr *MockRepositoryMockRecorder) GetDepartmentSubtree(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentSubtree", reflect.TypeOf((*MockRepository)(nil).GetDepartmentSubtree), ctx, id)
+}
+
+// GetEmployeeByID mocks base method.
+func (m *MockRepository) GetEmployeeByID(ctx context.Context, id int32) (*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeByID", ctx, id)
+	ret0, _ := ret[0].(*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployeeByID indicates an expected call of GetEmployeeByID.
+func (mr *MockRepositoryMockRecorder) GetEmployeeByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByID", reflect.TypeOf((*MockRepository)(nil).GetEmployeeByID), ctx, id)
+}
+
+// GetEmployeeScope mocks base method.
+func (m *MockRepository) GetEmployeeScope(ctx context.Context, id int32) (*models.EmployeeScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeScope", ctx, id)
+	ret0, _ := ret[0].(*models.EmployeeScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployeeScope indicates an expected call of GetEmployeeScope.
+func (mr *MockRepositoryMockRecorder) GetEmployeeScope(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeScope", reflect.TypeOf((*MockRepository)(nil).GetEmployeeScope), ctx, id)
+}
+
+// GetIdentityDocument mocks base method.
+func (m *MockRepository) GetIdentityDocument(ctx context.Context, employeeID, id int32) (*models.IdentityDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentityDocument", ctx, employeeID, id)
+	ret0, _ := ret[0].(*models.IdentityDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentityDocument indicates an expected call of GetIdentityDocument.
+func (mr *MockRepositoryMockRecorder) GetIdentityDocument(ctx, employeeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentityDocument", reflect.TypeOf((*MockRepository)(nil).GetIdentityDocument), ctx, employeeID, id)
+}
+
+// GetReportingChain mocks base method.
+func (m *MockRepository) GetReportingChain(ctx context.Context, id int32) ([]*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportingChain", ctx, id)
+	ret0, _ := ret[0].([]*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportingChain indicates an expected call of GetReportingChain.
+func (mr *MockRepositoryMockRecorder) GetReportingChain(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportingChain", reflect.TypeOf((*MockRepository)(nil).GetReportingChain), ctx, id)
+}
+
+// ImportEmployees mocks base method.
+func (m *MockRepository) ImportEmployees(ctx context.Context, employees []*models.Employee) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEmployees", ctx, employees)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportEmployees indicates an expected call of ImportEmployees.
+func (mr *MockRepositoryMockRecorder) ImportEmployees(ctx, employees any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEmployees", reflect.TypeOf((*MockRepository)(nil).ImportEmployees), ctx, employees)
+}
+
+// ListArchivedEmployees mocks base method.
+func (m *MockRepository) ListArchivedEmployees(ctx context.Context, companyID int32, pagination *models.Pagination) ([]*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArchivedEmployees", ctx, companyID, pagination)
+	ret0, _ := ret[0].([]*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArchivedEmployees indicates an expected call of ListArchivedEmployees.
+func (mr *MockRepositoryMockRecorder) ListArchivedEmployees(ctx, companyID, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArchivedEmployees", reflect.TypeOf((*MockRepository)(nil).ListArchivedEmployees), ctx, companyID, pagination)
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockRepository) ListAuditRecords(ctx context.Context, entityType string, entityID int32, pagination *models.Pagination) ([]*models.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, entityType, entityID, pagination)
+	ret0, _ := ret[0].([]*models.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockRepositoryMockRecorder) ListAuditRecords(ctx, entityType, entityID, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockRepository)(nil).ListAuditRecords), ctx, entityType, entityID, pagination)
+}
+
+// ListDirectReports mocks base method.
+func (m *MockRepository) ListDirectReports(ctx context.Context, managerID int32) ([]*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectReports", ctx, managerID)
+	ret0, _ := ret[0].([]*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectReports indicates an expected call of ListDirectReports.
+func (mr *MockRepositoryMockRecorder) ListDirectReports(ctx, managerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectReports", reflect.TypeOf((*MockRepository)(nil).ListDirectReports), ctx, managerID)
+}
+
+// ListDueAssignments mocks base method.
+func (m *MockRepository) ListDueAssignments(ctx context.Context, date time.Time, limit int32) ([]*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueAssignments", ctx, date, limit)
+	ret0, _ := ret[0].([]*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueAssignments indicates an expected call of ListDueAssignments.
+func (mr *MockRepositoryMockRecorder) ListDueAssignments(ctx, date, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueAssignments", reflect.TypeOf((*MockRepository)(nil).ListDueAssignments), ctx, date, limit)
+}
+
+// ListEmployeeAssignments mocks base method.
+func (m *MockRepository) ListEmployeeAssignments(ctx context.Context, employeeID int32) ([]*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmployeeAssignments", ctx, employeeID)
+	ret0, _ := ret[0].([]*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmployeeAssignments indicates an expected call of ListEmployeeAssignments.
+func (mr *MockRepositoryMockRecorder) ListEmployeeAssignments(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployeeAssignments", reflect.TypeOf((*MockRepository)(nil).ListEmployeeAssignments), ctx, employeeID)
+}
+
+// ListEmployees mocks base method.
+func (m *MockRepository) ListEmployees(ctx context.Context, params *models.EmployeeListParams, cursor *models.EmployeeCursor) ([]*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmployees", ctx, params, cursor)
+	ret0, _ := ret[0].([]*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmployees indicates an expected call of ListEmployees.
+func (mr *MockRepositoryMockRecorder) ListEmployees(ctx, params, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployees", reflect.TypeOf((*MockRepository)(nil).ListEmployees), ctx, params, cursor)
+}
+
+// ListIdentityDocuments mocks base method.
+func (m *MockRepository) ListIdentityDocuments(ctx context.Context, employeeID int32) ([]*models.IdentityDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentityDocuments", ctx, employeeID)
+	ret0, _ := ret[0].([]*models.IdentityDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIdentityDocuments indicates an expected call of ListIdentityDocuments.
+func (mr *MockRepositoryMockRecorder) ListIdentityDocuments(ctx, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentityDocuments", reflect.TypeOf((*MockRepository)(nil).ListIdentityDocuments), ctx, employeeID)
+}
+
+// PurgeEmployees mocks base method.
+func (m *MockRepository) PurgeEmployees(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeEmployees", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeEmployees indicates an expected call of PurgeEmployees.
+func (mr *MockRepositoryMockRecorder) PurgeEmployees(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeEmployees", reflect.TypeOf((*MockRepository)(nil).PurgeEmployees), ctx, deletedBefore)
+}
+
+// RestoreEmployee mocks base method.
+func (m *MockRepository) RestoreEmployee(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreEmployee", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreEmployee indicates an expected call of RestoreEmployee.
+func (mr *MockRepositoryMockRecorder) RestoreEmployee(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEmployee", reflect.TypeOf((*MockRepository)(nil).RestoreEmployee), ctx, id)
+}
+
+// RotateIdentityDocuments mocks base method.
+func (m *MockRepository) RotateIdentityDocuments(ctx context.Context, batchSize int32, progress func(*models.KeyRotation)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateIdentityDocuments", ctx, batchSize, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateIdentityDocuments indicates an expected call of RotateIdentityDocuments.
+func (mr *MockRepositoryMockRecorder) RotateIdentityDocuments(ctx, batchSize, progress any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListCompanyEmployees", reflect.TypeOf((*MockRepository)(nil).GetListCompanyEmployees), ctx, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateIdentityDocuments", reflect.TypeOf((*MockRepository)(nil).RotateIdentityDocuments), ctx, batchSize, progress)
 }
 
-// GetListDepartmentEmployees mocks base method.
-func (m *MockRepository) GetListDepartmentEmployees(ctx context.Context, departmentID int32) ([]*models.Employee, error) {
+// SearchEmployees mocks base method.
+func (m *MockRepository) SearchEmployees(ctx context.Context, params *models.EmployeeSearchParams) ([]*models.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListDepartmentEmployees", ctx, departmentID)
+	ret := m.ctrl.Call(m, "SearchEmployees", ctx, params)
 	ret0, _ := ret[0].([]*models.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetListDepartmentEmployees indicates an expected call of GetListDepartmentEmployees.
-func (mr *MockRepositoryMockRecorder) GetListDepartmentEmployees(ctx, departmentID any) *gomock.Call {
+// SearchEmployees indicates an expected call of SearchEmployees.
+func (mr *MockRepositoryMockRecorder) SearchEmployees(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDepartmentEmployees", reflect.TypeOf((*MockRepository)(nil).GetListDepartmentEmployees), ctx, departmentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEmployees", reflect.TypeOf((*MockRepository)(nil).SearchEmployees), ctx, params)
 }
@@ -0,0 +1,184 @@
+package repo
+
+import (
+	"context"
+	"employees/gen"
+	"employees/internal/models"
+	"employees/internal/pkg/audit"
+	"employees/internal/pkg/errs"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"time"
+)
+
+// recordAssignment adds an applied entry to the department timeline, fromDepartmentID is 0 for a new employee
+func (r *PostgresRepo) recordAssignment(ctx context.Context, queries *gen.Queries, employeeID, fromDepartmentID, departmentID int32) error {
+	err := queries.RecordAssignment(ctx, gen.RecordAssignmentParams{
+		EmployeeID:       employeeID,
+		FromDepartmentID: pgtype.Int4{Int32: fromDepartmentID, Valid: fromDepartmentID != 0},
+		DepartmentID:     departmentID,
+		Actor:            audit.ActorFromContext(ctx),
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "record assignment", "error", err)
+		return translateError(err)
+	}
+	return nil
+}
+
+// CreateAssignment schedules a transfer, it takes effect once ApplyAssignment runs
+func (r *PostgresRepo) CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error) {
+	effectiveDate, err := time.Parse(models.DateLayout, assignment.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.conn(ctx).CreateAssignment(ctx, gen.CreateAssignmentParams{
+		EmployeeID:       assignment.EmployeeID,
+		FromDepartmentID: pgtype.Int4{Int32: lo.FromPtr(assignment.FromDepartmentID), Valid: assignment.FromDepartmentID != nil},
+		DepartmentID:     assignment.DepartmentID,
+		EffectiveDate:    pgtype.Date{Time: effectiveDate, Valid: true},
+		Reason:           assignment.Reason,
+		Actor:            audit.ActorFromContext(ctx),
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "create assignment", "error", err)
+		return nil, translateError(err)
+	}
+
+	created := *assignment
+	created.ID = row.ID
+	created.Status = models.AssignmentScheduled
+	created.Actor = audit.ActorFromContext(ctx)
+	created.CreatedAt = row.CreatedAt.Time
+	return &created, nil
+}
+
+// ApplyAssignment moves the employee to the department of a scheduled assignment
+func (r *PostgresRepo) ApplyAssignment(ctx context.Context, id int64) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		assignment, err := queries.GetAssignmentForUpdate(ctx, id)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get assignment", "error", err)
+			return translateError(err)
+		}
+		if assignment.Status != models.AssignmentScheduled {
+			return fmt.Errorf("%w: assignment %d is %s", errs.ErrConflict, id, assignment.Status)
+		}
+
+		oldEmployee, err := queries.GetEmployeeForUpdate(ctx, assignment.EmployeeID)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get employee", "error", err)
+			return translateError(err)
+		}
+		employee, err := r.lockedEmployee(ctx, oldEmployee)
+		if err != nil {
+			return err
+		}
+		before := employeeSnapshot(employee)
+		employee.Department.ID = assignment.DepartmentID
+
+		if _, err = queries.UpdateEmployee(ctx, gen.UpdateEmployeeParams{
+			ID:           employee.ID,
+			Name:         employee.Name,
+			Surname:      employee.Surname,
+			Phone:        employee.Phone,
+			CompanyID:    employee.CompanyID,
+			DepartmentID: employee.Department.ID,
+			ManagerID:    int4(employee.ManagerID),
+		}); err != nil {
+			r.log.ErrorContext(ctx, "update employee", "error", err)
+			return translateError(err)
+		}
+
+		if err = queries.ApplyAssignment(ctx, gen.ApplyAssignmentParams{
+			ID:               id,
+			FromDepartmentID: pgtype.Int4{Int32: oldEmployee.DepartmentID, Valid: true},
+		}); err != nil {
+			r.log.ErrorContext(ctx, "apply assignment", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditEmployee,
+			entityID:   employee.ID,
+			operation:  models.AuditUpdate,
+			before:     before,
+			after:      employeeSnapshot(employee),
+		})
+	})
+}
+
+func (r *PostgresRepo) CancelAssignment(ctx context.Context, id int64) error {
+	if err := r.conn(ctx).CancelAssignment(ctx, id); err != nil {
+		r.log.ErrorContext(ctx, "cancel assignment", "error", err)
+		return translateError(err)
+	}
+	return nil
+}
+
+// ListDueAssignments locks scheduled assignments effective on or before the date,
+// rows locked by another worker are skipped. It must run inside a transaction.
+func (r *PostgresRepo) ListDueAssignments(ctx context.Context, date time.Time, limit int32) ([]*models.Assignment, error) {
+	assignments, err := r.conn(ctx).ListDueAssignments(ctx, gen.ListDueAssignmentsParams{
+		EffectiveDate: pgtype.Date{Time: date, Valid: true},
+		Limit:         limit,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "get due assignments", "error", err)
+		return nil, translateError(err)
+	}
+
+	listAssignments := make([]*models.Assignment, len(assignments))
+	for i, assignment := range assignments {
+		listAssignments[i] = &models.Assignment{
+			ID:               assignment.ID,
+			EmployeeID:       assignment.EmployeeID,
+			FromDepartmentID: int4Ptr(assignment.FromDepartmentID),
+			DepartmentID:     assignment.DepartmentID,
+			EffectiveDate:    assignment.EffectiveDate.Time.Format(models.DateLayout),
+			Status:           assignment.Status,
+			Reason:           assignment.Reason,
+			Actor:            assignment.Actor,
+			CreatedAt:        assignment.CreatedAt.Time,
+		}
+	}
+	return listAssignments, nil
+}
+
+func (r *PostgresRepo) ListEmployeeAssignments(ctx context.Context, employeeID int32) ([]*models.Assignment, error) {
+	assignments, err := r.conn(ctx).ListEmployeeAssignments(ctx, employeeID)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get employee assignments", "error", err)
+		return nil, translateError(err)
+	}
+
+	listAssignments := make([]*models.Assignment, len(assignments))
+	for i, assignment := range assignments {
+		listAssignments[i] = &models.Assignment{
+			ID:                 assignment.ID,
+			EmployeeID:         assignment.EmployeeID,
+			FromDepartmentID:   int4Ptr(assignment.FromDepartmentID),
+			FromDepartmentName: assignment.FromDepartmentName.String,
+			DepartmentID:       assignment.DepartmentID,
+			DepartmentName:     assignment.DepartmentName,
+			EffectiveDate:      assignment.EffectiveDate.Time.Format(models.DateLayout),
+			Status:             assignment.Status,
+			Reason:             assignment.Reason,
+			Actor:              assignment.Actor,
+			CreatedAt:          assignment.CreatedAt.Time,
+		}
+		if assignment.AppliedAt.Valid {
+			listAssignments[i].AppliedAt = lo.ToPtr(assignment.AppliedAt.Time)
+		}
+	}
+	return listAssignments, nil
+}
+
+func int4Ptr(value pgtype.Int4) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return lo.ToPtr(value.Int32)
+}
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"context"
+	"employees/gen"
+	"employees/internal/models"
+	"employees/internal/pkg/audit"
+	"employees/internal/pkg/pii"
+	"encoding/json"
+	"maps"
+	"sort"
+)
+
+// snapshot is the state of a record as it is stored in the audit log, keyed by column name
+type snapshot map[string]any
+
+type auditRecord struct {
+	entityType string
+	entityID   int32
+	operation  string
+	before     snapshot
+	after      snapshot
+}
+
+func employeeSnapshot(employee *models.Employee) snapshot {
+	return snapshot{
+		"name":            employee.Name,
+		"surname":         employee.Surname,
+		"phone":           employee.Phone,
+		"company_id":      employee.CompanyID,
+		"department_id":   employee.Department.ID,
+		"passport_type":   employee.Passport.Type,
+		"passport_number": employee.Passport.Number,
+		"manager_id":      optionalID(employee.ManagerID),
+	}
+}
+
+func departmentSnapshot(department *models.Department) snapshot {
+	return snapshot{
+		"name":                 department.Name,
+		"phone":                department.Phone,
+		"company_id":           department.CompanyID,
+		"parent_department_id": optionalID(department.ParentID),
+	}
+}
+
+// optionalID stores a missing reference as null, snapshot values are compared by value
+func optionalID(id *int32) any {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+func companySnapshot(company *models.Company) snapshot {
+	return snapshot{
+		"name": company.Name,
+	}
+}
+
+func documentSnapshot(document *models.IdentityDocument) snapshot {
+	return snapshot{
+		"employee_id": document.EmployeeID,
+		"type":        document.Type,
+		"number":      document.Number,
+		"issuer":      document.Issuer,
+		"issue_date":  document.IssueDate,
+		"expiry_date": document.ExpiryDate,
+		"country":     document.Country,
+		"is_primary":  document.Primary,
+	}
+}
+
+// changedFields lists the columns that differ between two snapshots, a missing snapshot differs in every column
+func changedFields(before, after snapshot) []string {
+	fields := make([]string, 0, len(before)+len(after))
+	for field, value := range after {
+		if old, ok := before[field]; !ok || old != value {
+			fields = append(fields, field)
+		}
+	}
+	for field := range before {
+		if _, ok := after[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// maskedFields hold document numbers, the audit log only keeps them masked
+var maskedFields = []string{"passport_number", "number"}
+
+// marshalSnapshot stores the snapshot with the document numbers masked,
+// changed fields are computed before masking so that a changed number is still recorded
+func marshalSnapshot(s snapshot) ([]byte, error) {
+	if s == nil {
+		return nil, nil
+	}
+	masked := maps.Clone(s)
+	for _, field := range maskedFields {
+		if value, ok := masked[field].(string); ok {
+			masked[field] = pii.Mask(value)
+		}
+	}
+	return json.Marshal(masked)
+}
+
+func newAuditParams(ctx context.Context, record auditRecord) (gen.CreateAuditRecordParams, error) {
+	before, err := marshalSnapshot(record.before)
+	if err != nil {
+		return gen.CreateAuditRecordParams{}, err
+	}
+	after, err := marshalSnapshot(record.after)
+	if err != nil {
+		return gen.CreateAuditRecordParams{}, err
+	}
+
+	return gen.CreateAuditRecordParams{
+		EntityType:    record.entityType,
+		EntityID:      record.entityID,
+		Operation:     record.operation,
+		Actor:         audit.ActorFromContext(ctx),
+		Before:        before,
+		After:         after,
+		ChangedFields: changedFields(record.before, record.after),
+	}, nil
+}
+
+// writeAudit stores the record with the queries of the transaction that made the change
+func (r *PostgresRepo) writeAudit(ctx context.Context, queries *gen.Queries, record auditRecord) error {
+	params, err := newAuditParams(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	if err = queries.CreateAuditRecord(ctx, params); err != nil {
+		r.log.ErrorContext(ctx, "write audit record", "error", err)
+		return translateError(err)
+	}
+	return nil
+}
+
+// copyAudit stores many records at once, for bulk changes
+func (r *PostgresRepo) copyAudit(ctx context.Context, queries *gen.Queries, records []auditRecord) error {
+	params := make([]gen.CopyAuditRecordsParams, len(records))
+	for i, record := range records {
+		p, err := newAuditParams(ctx, record)
+		if err != nil {
+			return err
+		}
+		params[i] = gen.CopyAuditRecordsParams(p)
+	}
+
+	if _, err := queries.CopyAuditRecords(ctx, params); err != nil {
+		r.log.ErrorContext(ctx, "copy audit records", "error", err)
+		return translateError(err)
+	}
+	return nil
+}
+
+func (r *PostgresRepo) ListAuditRecords(ctx context.Context, entityType string, entityID int32, pagination *models.Pagination) ([]*models.AuditRecord, error) {
+	records, err := r.conn(ctx).ListAuditRecords(ctx, gen.ListAuditRecordsParams{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Limit:      pagination.Limit,
+		Offset:     pagination.Offset,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "get audit records", "error", err)
+		return nil, translateError(err)
+	}
+
+	listRecords := make([]*models.AuditRecord, len(records))
+	for i, record := range records {
+		listRecords[i] = &models.AuditRecord{
+			ID:            record.ID,
+			Operation:     record.Operation,
+			Actor:         record.Actor,
+			Before:        record.Before,
+			After:         record.After,
+			ChangedFields: record.ChangedFields,
+			CreatedAt:     record.CreatedAt.Time,
+		}
+	}
+
+	return listRecords, nil
+}
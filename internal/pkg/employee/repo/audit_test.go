@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"employees/internal/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	employee := &models.Employee{
+		Name:       "katya",
+		Surname:    "ivanova",
+		Phone:      "+79161234567",
+		CompanyID:  1,
+		Passport:   models.Passport{Type: "РФ", Number: "7878 898989"},
+		Department: models.Department{ID: 2},
+	}
+	moved := *employee
+	moved.CompanyID = 3
+	moved.Department.ID = 4
+	moved.Passport.Number = "1111 222222"
+	withManager := func(id int32) *models.Employee {
+		managed := *employee
+		managed.ManagerID = &id
+		return &managed
+	}
+
+	testTable := []struct {
+		name     string
+		before   snapshot
+		after    snapshot
+		expected []string
+	}{
+		{
+			name:     "create",
+			after:    companySnapshot(&models.Company{Name: "company"}),
+			expected: []string{"name"},
+		},
+		{
+			name:     "delete",
+			before:   departmentSnapshot(&models.Department{Name: "dev", Phone: "123", CompanyID: 1}),
+			expected: []string{"company_id", "name", "parent_department_id", "phone"},
+		},
+		{
+			name:     "update",
+			before:   employeeSnapshot(employee),
+			after:    employeeSnapshot(&moved),
+			expected: []string{"company_id", "department_id", "passport_number"},
+		},
+		{
+			name:     "nothing changed",
+			before:   employeeSnapshot(employee),
+			after:    employeeSnapshot(employee),
+			expected: []string{},
+		},
+		{
+			name:     "same manager",
+			before:   employeeSnapshot(withManager(5)),
+			after:    employeeSnapshot(withManager(5)),
+			expected: []string{},
+		},
+		{
+			name:     "manager removed",
+			before:   employeeSnapshot(withManager(5)),
+			after:    employeeSnapshot(employee),
+			expected: []string{"manager_id"},
+		},
+	}
+
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, changedFields(tt.before, tt.after))
+		})
+	}
+}
+
+func TestMarshalSnapshot(t *testing.T) {
+	data, err := marshalSnapshot(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = marshalSnapshot(departmentSnapshot(&models.Department{Name: "dev", Phone: "123", CompanyID: 1}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"dev","phone":"123","company_id":1,"parent_department_id":null}`, string(data))
+}
+
+func TestMarshalSnapshotMasksNumbers(t *testing.T) {
+	employee := employeeSnapshot(&models.Employee{Passport: models.Passport{Type: "РФ", Number: "7878 898989"}})
+	data, err := marshalSnapshot(employee)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"passport_number":"****8989"`)
+	assert.Equal(t, "7878 898989", employee["passport_number"])
+
+	data, err = marshalSnapshot(documentSnapshot(&models.IdentityDocument{Type: "РФ", Number: "7878 898989"}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"number":"****8989"`)
+}
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"employees/internal/models"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+// ApplyEmployeeChanges runs the changes in one transaction, each inside its own savepoint so that
+// a failed change is undone without aborting the others. In atomic mode the first failure stops
+// the batch and nothing is committed; the results then end at the failed change.
+func (r *PostgresRepo) ApplyEmployeeChanges(ctx context.Context, changes []*models.EmployeeChange, atomic bool) ([]models.EmployeeChangeResult, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		r.log.ErrorContext(ctx, "begin transaction", "error", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	results := make([]models.EmployeeChangeResult, 0, len(changes))
+	for _, change := range changes {
+		result, err := r.applyEmployeeChange(ctx, tx, change)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+		if atomic && result.Err != nil {
+			return results, nil
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		r.log.ErrorContext(ctx, "commit transaction", "error", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// applyEmployeeChange reports the change failure in the result, the error is only for savepoint failures
+func (r *PostgresRepo) applyEmployeeChange(ctx context.Context, tx pgx.Tx, change *models.EmployeeChange) (models.EmployeeChangeResult, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		r.log.ErrorContext(ctx, "create savepoint", "error", err)
+		return models.EmployeeChangeResult{}, err
+	}
+
+	queries := r.queries.WithTx(savepoint)
+	result := models.EmployeeChangeResult{ID: change.ID}
+	switch change.Op {
+	case models.BatchCreate:
+		result.ID, result.Err = r.createEmployee(ctx, queries, change.Employee)
+	case models.BatchUpdate:
+		result.Version, result.Err = r.editEmployee(ctx, queries, change.ID, change.Update)
+	case models.BatchDelete:
+		result.Err = r.deleteEmployee(ctx, queries, change.ID, change.Version)
+	default:
+		result.Err = fmt.Errorf("unknown batch operation %q", change.Op)
+	}
+
+	if result.Err != nil {
+		if err = savepoint.Rollback(ctx); err != nil {
+			r.log.ErrorContext(ctx, "rollback to savepoint", "error", err)
+			return models.EmployeeChangeResult{}, err
+		}
+		return result, nil
+	}
+	if err = savepoint.Commit(ctx); err != nil {
+		r.log.ErrorContext(ctx, "release savepoint", "error", err)
+		return models.EmployeeChangeResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,312 @@
+package repo
+
+import (
+	"context"
+	"employees/gen"
+	"employees/internal/models"
+	"employees/internal/pkg/errs"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// ListIdentityDocuments returns the documents of the employee, the primary one first
+func (r *PostgresRepo) ListIdentityDocuments(ctx context.Context, employeeID int32) ([]*models.IdentityDocument, error) {
+	documents, err := r.conn(ctx).ListIdentityDocuments(ctx, employeeID)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get identity documents", "error", err)
+		return nil, translateError(err)
+	}
+
+	listDocuments := make([]*models.IdentityDocument, len(documents))
+	for i, document := range documents {
+		if listDocuments[i], err = r.identityDocument(ctx, document); err != nil {
+			return nil, err
+		}
+	}
+	return listDocuments, nil
+}
+
+func (r *PostgresRepo) GetIdentityDocument(ctx context.Context, employeeID, id int32) (*models.IdentityDocument, error) {
+	document, err := r.conn(ctx).GetIdentityDocument(ctx, gen.GetIdentityDocumentParams{
+		ID:         id,
+		EmployeeID: employeeID,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "get identity document", "error", err)
+		return nil, translateError(err)
+	}
+	return r.identityDocument(ctx, document)
+}
+
+// CreateIdentityDocument adds a document to an active employee. A primary document
+// replaces the previous one as the employee passport, the first document is always primary.
+func (r *PostgresRepo) CreateIdentityDocument(ctx context.Context, document *models.IdentityDocument) (int32, error) {
+	var documentID int32
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		row, err := queries.GetEmployeeForUpdate(ctx, document.EmployeeID)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get employee", "error", err)
+			return translateError(err)
+		}
+		employee, err := r.lockedEmployee(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		created := *document
+		created.Primary = created.Primary || employee.Passport.Number == ""
+		issueDate, expiryDate, err := documentDates(&created)
+		if err != nil {
+			return err
+		}
+		number, err := r.sealNumber(ctx, created.Number)
+		if err != nil {
+			return err
+		}
+		if created.Primary {
+			if err = queries.ClearPrimaryIdentityDocument(ctx, created.EmployeeID); err != nil {
+				r.log.ErrorContext(ctx, "clear primary identity document", "error", err)
+				return translateError(err)
+			}
+		}
+
+		documentID, err = queries.CreateIdentityDocument(ctx, gen.CreateIdentityDocumentParams{
+			EmployeeID:       created.EmployeeID,
+			Type:             created.Type,
+			NumberHash:       number.hash,
+			NumberKeyID:      number.keyID,
+			NumberCiphertext: number.ciphertext,
+			Issuer:           created.Issuer,
+			IssueDate:        issueDate,
+			ExpiryDate:       expiryDate,
+			Country:          created.Country,
+			IsPrimary:        created.Primary,
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "create identity document", "error", err)
+			return translateError(err)
+		}
+		if created.Primary {
+			if err = r.changePassport(ctx, queries, employee, &created); err != nil {
+				return err
+			}
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditDocument,
+			entityID:   documentID,
+			operation:  models.AuditCreate,
+			after:      documentSnapshot(&created),
+		})
+	})
+	return documentID, err
+}
+
+// EditIdentityDocument locks the employee and the document, lets update modify the loaded document
+// and writes the result back in the same transaction
+func (r *PostgresRepo) EditIdentityDocument(ctx context.Context, employeeID, id int32, update func(document *models.IdentityDocument) error) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		lockedRow, err := queries.GetEmployeeForUpdate(ctx, employeeID)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get employee", "error", err)
+			return translateError(err)
+		}
+		employee, err := r.lockedEmployee(ctx, lockedRow)
+		if err != nil {
+			return err
+		}
+		row, err := queries.GetIdentityDocumentForUpdate(ctx, gen.GetIdentityDocumentForUpdateParams{
+			ID:         id,
+			EmployeeID: employeeID,
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "get identity document", "error", err)
+			return translateError(err)
+		}
+
+		document, err := r.identityDocument(ctx, row)
+		if err != nil {
+			return err
+		}
+		before := documentSnapshot(document)
+		if err = update(document); err != nil {
+			return err
+		}
+		issueDate, expiryDate, err := documentDates(document)
+		if err != nil {
+			return err
+		}
+		number, err := r.sealNumber(ctx, document.Number)
+		if err != nil {
+			return err
+		}
+		if document.Primary && !row.IsPrimary {
+			if err = queries.ClearPrimaryIdentityDocument(ctx, employeeID); err != nil {
+				r.log.ErrorContext(ctx, "clear primary identity document", "error", err)
+				return translateError(err)
+			}
+		}
+
+		err = queries.UpdateIdentityDocument(ctx, gen.UpdateIdentityDocumentParams{
+			ID:               id,
+			Type:             document.Type,
+			NumberHash:       number.hash,
+			NumberKeyID:      number.keyID,
+			NumberCiphertext: number.ciphertext,
+			Issuer:           document.Issuer,
+			IssueDate:        issueDate,
+			ExpiryDate:       expiryDate,
+			Country:          document.Country,
+			IsPrimary:        document.Primary,
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "update identity document", "error", err)
+			return translateError(err)
+		}
+		if document.Primary {
+			if err = r.changePassport(ctx, queries, employee, document); err != nil {
+				return err
+			}
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditDocument,
+			entityID:   id,
+			operation:  models.AuditUpdate,
+			before:     before,
+			after:      documentSnapshot(document),
+		})
+	})
+}
+
+// DeleteIdentityDocument removes a document of an active employee, the primary document
+// can only be removed after another one became primary
+func (r *PostgresRepo) DeleteIdentityDocument(ctx context.Context, employeeID, id int32) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		if _, err := queries.GetEmployeeForUpdate(ctx, employeeID); err != nil {
+			r.log.ErrorContext(ctx, "get employee", "error", err)
+			return translateError(err)
+		}
+		row, err := queries.GetIdentityDocumentForUpdate(ctx, gen.GetIdentityDocumentForUpdateParams{
+			ID:         id,
+			EmployeeID: employeeID,
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "get identity document", "error", err)
+			return translateError(err)
+		}
+		if row.IsPrimary {
+			return fmt.Errorf("%w: identity document %d is the primary one", errs.ErrConflict, id)
+		}
+		document, err := r.identityDocument(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		if _, err = queries.DeleteIdentityDocument(ctx, id); err != nil {
+			r.log.ErrorContext(ctx, "delete identity document", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditDocument,
+			entityID:   id,
+			operation:  models.AuditDelete,
+			before:     documentSnapshot(document),
+		})
+	})
+}
+
+// savePassport stores the passport of a created or edited employee as its primary document
+func (r *PostgresRepo) savePassport(ctx context.Context, queries *gen.Queries, employeeID int32, passport models.Passport) error {
+	number, err := r.sealNumber(ctx, passport.Number)
+	if err != nil {
+		return err
+	}
+	err = queries.UpsertPrimaryIdentityDocument(ctx, gen.UpsertPrimaryIdentityDocumentParams{
+		EmployeeID:       employeeID,
+		Type:             passport.Type,
+		NumberHash:       number.hash,
+		NumberKeyID:      number.keyID,
+		NumberCiphertext: number.ciphertext,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "save passport", "error", err)
+		return translateError(err)
+	}
+	return nil
+}
+
+// changePassport records that the primary document changed the passport of the locked employee,
+// the employee gets a new version so that stale ETags stop matching
+func (r *PostgresRepo) changePassport(ctx context.Context, queries *gen.Queries, employee *models.Employee, document *models.IdentityDocument) error {
+	passport := models.Passport{Type: document.Type, Number: document.Number}
+	if employee.Passport == passport {
+		return nil
+	}
+	before := employeeSnapshot(employee)
+	employee.Passport = passport
+
+	if err := queries.BumpEmployeeVersion(ctx, employee.ID); err != nil {
+		r.log.ErrorContext(ctx, "bump employee version", "error", err)
+		return translateError(err)
+	}
+
+	return r.writeAudit(ctx, queries, auditRecord{
+		entityType: models.AuditEmployee,
+		entityID:   employee.ID,
+		operation:  models.AuditUpdate,
+		before:     before,
+		after:      employeeSnapshot(employee),
+	})
+}
+
+func (r *PostgresRepo) identityDocument(ctx context.Context, document gen.IdentityDocument) (*models.IdentityDocument, error) {
+	number, err := r.openNumber(ctx, document.Number.String, document.NumberKeyID, document.NumberCiphertext)
+	if err != nil {
+		return nil, err
+	}
+	return &models.IdentityDocument{
+		ID:         document.ID,
+		EmployeeID: document.EmployeeID,
+		Type:       document.Type,
+		Number:     number,
+		Issuer:     document.Issuer,
+		IssueDate:  formatDate(document.IssueDate),
+		ExpiryDate: formatDate(document.ExpiryDate),
+		Country:    document.Country,
+		Primary:    document.IsPrimary,
+	}, nil
+}
+
+func documentDates(document *models.IdentityDocument) (pgtype.Date, pgtype.Date, error) {
+	issueDate, err := parseDate(document.IssueDate)
+	if err != nil {
+		return pgtype.Date{}, pgtype.Date{}, err
+	}
+	expiryDate, err := parseDate(document.ExpiryDate)
+	if err != nil {
+		return pgtype.Date{}, pgtype.Date{}, err
+	}
+	return issueDate, expiryDate, nil
+}
+
+// parseDate converts an optional date in models.DateLayout, an empty value is stored as null
+func parseDate(value string) (pgtype.Date, error) {
+	if value == "" {
+		return pgtype.Date{}, nil
+	}
+	date, err := time.Parse(models.DateLayout, value)
+	if err != nil {
+		return pgtype.Date{}, err
+	}
+	return pgtype.Date{Time: date, Valid: true}, nil
+}
+
+func formatDate(date pgtype.Date) string {
+	if !date.Valid {
+		return ""
+	}
+	return date.Time.Format(models.DateLayout)
+}
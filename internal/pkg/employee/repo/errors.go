@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"employees/internal/pkg/errs"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeStringDataRightTruncation = "22001"
+	codeNotNullViolation          = "23502"
+	codeForeignKeyViolation       = "23503"
+	codeUniqueViolation           = "23505"
+	codeCheckViolation            = "23514"
+)
+
+const constraintDepartmentCompany = "employees_department_company_fkey"
+
+// translateError converts pgx errors into domain errors from the errs package.
+// Errors without a domain meaning are returned unchanged.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errs.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case codeUniqueViolation:
+		return fmt.Errorf("%w: %s", errs.ErrConflict, pgErr.ConstraintName)
+	case codeForeignKeyViolation:
+		if pgErr.ConstraintName == constraintDepartmentCompany {
+			return errs.ErrDepartmentCompanyMismatch
+		}
+		return fmt.Errorf("%w: %s", errs.ErrInvalidReference, pgErr.ConstraintName)
+	case codeCheckViolation, codeNotNullViolation, codeStringDataRightTruncation:
+		return fmt.Errorf("%w: %s", errs.ErrValidation, pgErr.Message)
+	default:
+		return err
+	}
+}
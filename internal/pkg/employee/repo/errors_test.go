@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"employees/internal/pkg/errs"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	otherErr := errors.New("connection refused")
+
+	testTable := []struct {
+		name     string
+		input    error
+		expected error
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "no rows",
+			input:    fmt.Errorf("query: %w", pgx.ErrNoRows),
+			expected: errs.ErrNotFound,
+		},
+		{
+			name:     "unique violation",
+			input:    &pgconn.PgError{Code: "23505", ConstraintName: "employees_phone_key"},
+			expected: errs.ErrConflict,
+		},
+		{
+			name:     "foreign key violation",
+			input:    &pgconn.PgError{Code: "23503", ConstraintName: "employees_company_id_fkey"},
+			expected: errs.ErrInvalidReference,
+		},
+		{
+			name:     "department of another company",
+			input:    &pgconn.PgError{Code: "23503", ConstraintName: "employees_department_company_fkey"},
+			expected: errs.ErrDepartmentCompanyMismatch,
+		},
+		{
+			name:     "check violation",
+			input:    &pgconn.PgError{Code: "23514", ConstraintName: "phone_length"},
+			expected: errs.ErrValidation,
+		},
+		{
+			name:     "unknown error",
+			input:    otherErr,
+			expected: otherErr,
+		},
+	}
+
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.input)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"employees/internal/models"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// exportBatchSize is how many rows one FETCH pulls from the export cursor
+const exportBatchSize = 500
+
+// sqlc can only return whole result sets, so the export cursor is declared by hand
+const declareExportCursor = `DECLARE export_employees NO SCROLL CURSOR FOR
+SELECT e.id, e.name, e.surname, e.phone, e.company_id, COALESCE(p.type, ''), COALESCE(p.number, ''),
+       p.number_key_id, p.number_ciphertext, e.created_at, d.id, d.name, d.phone
+FROM employees e
+JOIN departments d ON e.department_id = d.id
+LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = $1 AND e.deleted_at IS NULL
+ORDER BY e.id`
+
+var fetchExportCursor = fmt.Sprintf("FETCH %d FROM export_employees", exportBatchSize)
+
+// ExportEmployees streams the company employees to fn through a server side cursor,
+// only one batch of rows is held in memory at a time
+func (r *PostgresRepo) ExportEmployees(ctx context.Context, companyID int32, fn func(employee *models.Employee) error) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		r.log.ErrorContext(ctx, "begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err = tx.Exec(ctx, declareExportCursor, companyID); err != nil {
+		r.log.ErrorContext(ctx, "declare export cursor", "error", err)
+		return translateError(err)
+	}
+
+	for {
+		fetched, err := r.fetchExportBatch(ctx, tx, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < exportBatchSize {
+			break
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepo) fetchExportBatch(ctx context.Context, tx pgx.Tx, fn func(employee *models.Employee) error) (int, error) {
+	rows, err := tx.Query(ctx, fetchExportCursor)
+	if err != nil {
+		r.log.ErrorContext(ctx, "fetch export cursor", "error", err)
+		return 0, translateError(err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		var (
+			employee   models.Employee
+			keyID      pgtype.Text
+			ciphertext []byte
+		)
+		if err = rows.Scan(
+			&employee.ID,
+			&employee.Name,
+			&employee.Surname,
+			&employee.Phone,
+			&employee.CompanyID,
+			&employee.Passport.Type,
+			&employee.Passport.Number,
+			&keyID,
+			&ciphertext,
+			&employee.CreatedAt,
+			&employee.Department.ID,
+			&employee.Department.Name,
+			&employee.Department.Phone,
+		); err != nil {
+			r.log.ErrorContext(ctx, "scan exported employee", "error", err)
+			return 0, err
+		}
+		if employee.Passport.Number, err = r.openNumber(ctx, employee.Passport.Number, keyID, ciphertext); err != nil {
+			return 0, err
+		}
+		fetched++
+		if err = fn(&employee); err != nil {
+			return 0, err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		r.log.ErrorContext(ctx, "fetch export cursor", "error", err)
+		return 0, translateError(err)
+	}
+	return fetched, nil
+}
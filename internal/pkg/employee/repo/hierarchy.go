@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/errs"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+)
+
+// GetDepartmentSubtree returns the department followed by all its descendants in depth-first order
+func (r *PostgresRepo) GetDepartmentSubtree(ctx context.Context, id int32) ([]*models.Department, error) {
+	departments, err := r.conn(ctx).GetDepartmentSubtree(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get department subtree", "error", err)
+		return nil, translateError(err)
+	}
+	if len(departments) == 0 {
+		return nil, fmt.Errorf("%w: department %d", errs.ErrNotFound, id)
+	}
+
+	subtree := make([]*models.Department, len(departments))
+	for i, department := range departments {
+		subtree[i] = &models.Department{
+			ID:        department.ID,
+			Name:      department.Name,
+			Phone:     department.Phone,
+			CompanyID: department.CompanyID,
+			ParentID:  int4Ptr(department.ParentDepartmentID),
+		}
+	}
+	return subtree, nil
+}
+
+// GetReportingChain returns the employee followed by the managers above it up to the top,
+// the chain stops at an archived manager
+func (r *PostgresRepo) GetReportingChain(ctx context.Context, id int32) ([]*models.Employee, error) {
+	employees, err := r.conn(ctx).GetReportingChain(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get reporting chain", "error", err)
+		return nil, translateError(err)
+	}
+	if len(employees) == 0 {
+		return nil, fmt.Errorf("%w: employee %d", errs.ErrNotFound, id)
+	}
+
+	chain := make([]*models.Employee, len(employees))
+	for i, employee := range employees {
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return nil, err
+		}
+		chain[i] = &models.Employee{
+			ID:        employee.ID,
+			Name:      employee.Name,
+			Surname:   employee.Surname,
+			Phone:     employee.Phone,
+			CompanyID: employee.CompanyID,
+			Passport: models.Passport{
+				Type:   employee.PassportType,
+				Number: number,
+			},
+			Department: models.Department{
+				ID:    employee.ID_2,
+				Name:  employee.Name_2,
+				Phone: employee.Phone_2,
+			},
+			ManagerID: int4Ptr(employee.ManagerID),
+		}
+	}
+	return chain, nil
+}
+
+func (r *PostgresRepo) ListDirectReports(ctx context.Context, managerID int32) ([]*models.Employee, error) {
+	employees, err := r.conn(ctx).ListDirectReports(ctx, managerID)
+	if err != nil {
+		r.log.ErrorContext(ctx, "list direct reports", "error", err)
+		return nil, translateError(err)
+	}
+
+	reports := make([]*models.Employee, len(employees))
+	for i, employee := range employees {
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return nil, err
+		}
+		reports[i] = &models.Employee{
+			ID:        employee.ID,
+			Name:      employee.Name,
+			Surname:   employee.Surname,
+			Phone:     employee.Phone,
+			CompanyID: employee.CompanyID,
+			Passport: models.Passport{
+				Type:   employee.PassportType,
+				Number: number,
+			},
+			Department: models.Department{
+				ID:    employee.ID_2,
+				Name:  employee.Name_2,
+				Phone: employee.Phone_2,
+			},
+			ManagerID: int4Ptr(employee.ManagerID),
+		}
+	}
+	return reports, nil
+}
+
+func int4(value *int32) pgtype.Int4 {
+	return pgtype.Int4{Int32: lo.FromPtr(value), Valid: value != nil}
+}
@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+	"employees/gen"
+	"employees/internal/models"
+	"employees/internal/pkg/pii"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sealedNumber is a document number as it is stored: the blind index and the sealed value
+type sealedNumber struct {
+	hash       []byte
+	keyID      pgtype.Text
+	ciphertext []byte
+}
+
+func (r *PostgresRepo) sealNumber(ctx context.Context, number string) (sealedNumber, error) {
+	sealed, err := r.vault.Seal(number)
+	if err != nil {
+		r.log.ErrorContext(ctx, "seal document number", "error", err)
+		return sealedNumber{}, err
+	}
+	return sealedNumber{
+		hash:       r.vault.BlindIndex(number),
+		keyID:      pgtype.Text{String: sealed.KeyID, Valid: true},
+		ciphertext: sealed.Ciphertext,
+	}, nil
+}
+
+// openNumber returns the plaintext of a stored document number. Numbers written before
+// encryption was introduced stay in plaintext until EncryptIdentityDocuments seals them.
+func (r *PostgresRepo) openNumber(ctx context.Context, number string, keyID pgtype.Text, ciphertext []byte) (string, error) {
+	if !keyID.Valid {
+		return number, nil
+	}
+	plaintext, err := r.vault.Open(pii.Sealed{KeyID: keyID.String, Ciphertext: ciphertext})
+	if err != nil {
+		r.log.ErrorContext(ctx, "open document number", "key_id", keyID.String, "error", err)
+		return "", err
+	}
+	return plaintext, nil
+}
+
+// EncryptIdentityDocuments seals the document numbers still stored in plaintext. Every batch is sealed
+// in a transaction of its own, so the work survives an interruption and concurrent runs skip each other's rows.
+func (r *PostgresRepo) EncryptIdentityDocuments(ctx context.Context, batchSize int32) (int, error) {
+	total := 0
+	for {
+		var sealed int
+		err := r.inTx(ctx, func(queries *gen.Queries) error {
+			documents, err := queries.ListPlaintextIdentityDocuments(ctx, batchSize)
+			if err != nil {
+				r.log.ErrorContext(ctx, "get plaintext identity documents", "error", err)
+				return translateError(err)
+			}
+			for _, document := range documents {
+				number, err := r.sealNumber(ctx, document.Number)
+				if err != nil {
+					return err
+				}
+				err = queries.SealIdentityDocumentNumber(ctx, gen.SealIdentityDocumentNumberParams{
+					ID:               document.ID,
+					NumberHash:       number.hash,
+					NumberKeyID:      number.keyID,
+					NumberCiphertext: number.ciphertext,
+				})
+				if err != nil {
+					r.log.ErrorContext(ctx, "seal identity document number", "error", err)
+					return translateError(err)
+				}
+			}
+			sealed = len(documents)
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += sealed
+		if sealed < int(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// RotateIdentityDocuments reseals the document numbers sealed with other keys with the active key.
+// Every batch is resealed in one transaction with the checkpoint, so an interrupted rotation continues
+// after the last committed batch. progress is called after every batch and once the rotation is finished.
+func (r *PostgresRepo) RotateIdentityDocuments(ctx context.Context, batchSize int32, progress func(rotation *models.KeyRotation)) error {
+	keyID := pgtype.Text{String: r.vault.ActiveKeyID(), Valid: true}
+	row, err := r.conn(ctx).StartKeyRotation(ctx, keyID.String)
+	if err != nil {
+		r.log.ErrorContext(ctx, "start key rotation", "error", err)
+		return translateError(err)
+	}
+	remaining, err := r.conn(ctx).CountIdentityDocumentsToRotate(ctx, gen.CountIdentityDocumentsToRotateParams{
+		ID:          row.LastDocumentID,
+		NumberKeyID: keyID,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "count identity documents to rotate", "error", err)
+		return translateError(err)
+	}
+
+	rotation := &models.KeyRotation{
+		KeyID:          row.KeyID,
+		LastDocumentID: row.LastDocumentID,
+		Rotated:        row.Rotated,
+		Total:          row.Rotated + remaining,
+		StartedAt:      row.StartedAt.Time,
+	}
+	for {
+		rotated, lastID, err := r.rotateBatch(ctx, keyID, rotation.LastDocumentID, batchSize)
+		if err != nil {
+			return err
+		}
+		rotation.Rotated += int64(rotated)
+		rotation.LastDocumentID = lastID
+		if rotated < int(batchSize) {
+			break
+		}
+		progress(rotation)
+	}
+
+	if err = r.conn(ctx).FinishKeyRotation(ctx, keyID.String); err != nil {
+		r.log.ErrorContext(ctx, "finish key rotation", "error", err)
+		return translateError(err)
+	}
+	rotation.Finished = true
+	progress(rotation)
+	return nil
+}
+
+// rotateBatch reseals the documents after afterID and moves the checkpoint past them,
+// it returns how many documents were resealed and the new checkpoint
+func (r *PostgresRepo) rotateBatch(ctx context.Context, keyID pgtype.Text, afterID, batchSize int32) (int, int32, error) {
+	lastID := afterID
+	var rotated int
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		documents, err := queries.ListIdentityDocumentsToRotate(ctx, gen.ListIdentityDocumentsToRotateParams{
+			ID:          afterID,
+			NumberKeyID: keyID,
+			Limit:       batchSize,
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "get identity documents to rotate", "error", err)
+			return translateError(err)
+		}
+		if len(documents) == 0 {
+			return nil
+		}
+
+		for _, document := range documents {
+			number, err := r.openNumber(ctx, "", document.NumberKeyID, document.NumberCiphertext)
+			if err != nil {
+				return err
+			}
+			sealed, err := r.sealNumber(ctx, number)
+			if err != nil {
+				return err
+			}
+			err = queries.RotateIdentityDocumentNumber(ctx, gen.RotateIdentityDocumentNumberParams{
+				ID:               document.ID,
+				NumberKeyID:      sealed.keyID,
+				NumberCiphertext: sealed.ciphertext,
+			})
+			if err != nil {
+				r.log.ErrorContext(ctx, "rotate identity document number", "error", err)
+				return translateError(err)
+			}
+		}
+
+		lastID = documents[len(documents)-1].ID
+		rotated = len(documents)
+		if err = queries.UpdateKeyRotation(ctx, gen.UpdateKeyRotationParams{
+			KeyID:          keyID.String,
+			LastDocumentID: lastID,
+			Rotated:        int64(rotated),
+		}); err != nil {
+			r.log.ErrorContext(ctx, "update key rotation", "error", err)
+			return translateError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, afterID, err
+	}
+	return rotated, lastID, nil
+}
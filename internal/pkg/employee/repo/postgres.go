@@ -4,21 +4,32 @@ import (
 	"context"
 	"employees/gen"
 	"employees/internal/models"
+	"employees/internal/pkg/audit"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/pii"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/samber/lo"
 	"go.uber.org/fx"
 	"log/slog"
+	"strings"
+	"time"
 )
 
 type Params struct {
 	fx.In
 	DB     *pgxpool.Pool
+	Vault  *pii.Vault
 	Logger *slog.Logger
 }
 
 type PostgresRepo struct {
 	db      *pgxpool.Pool
 	queries *gen.Queries
+	vault   *pii.Vault
 	log     *slog.Logger
 }
 
@@ -26,45 +37,156 @@ func New(p Params) *PostgresRepo {
 	return &PostgresRepo{
 		db:      p.DB,
 		queries: gen.New(p.DB),
+		vault:   p.Vault,
 		log:     p.Logger,
 	}
 }
 
 func (r *PostgresRepo) CreateEmployee(ctx context.Context, employee *models.Employee) (int32, error) {
-	createEmployeeID, err := r.queries.CreateEmployee(ctx, gen.CreateEmployeeParams{
-		Name:           employee.Name,
-		Surname:        employee.Surname,
-		Phone:          employee.Phone,
-		CompanyID:      employee.CompanyID,
-		DepartmentID:   employee.Department.ID,
-		PassportType:   employee.Passport.Type,
-		PassportNumber: employee.Passport.Number,
+	var createEmployeeID int32
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		var err error
+		createEmployeeID, err = r.createEmployee(ctx, queries, employee)
+		return err
+	})
+	return createEmployeeID, err
+}
+
+func (r *PostgresRepo) createEmployee(ctx context.Context, queries *gen.Queries, employee *models.Employee) (int32, error) {
+	createEmployeeID, err := queries.CreateEmployee(ctx, gen.CreateEmployeeParams{
+		Name:         employee.Name,
+		Surname:      employee.Surname,
+		Phone:        employee.Phone,
+		CompanyID:    employee.CompanyID,
+		DepartmentID: employee.Department.ID,
+		ManagerID:    int4(employee.ManagerID),
 	})
 	if err != nil {
-		r.log.Error("create employee", "error", err)
+		r.log.ErrorContext(ctx, "create employee", "error", err)
+		return 0, translateError(err)
+	}
+	if err = r.savePassport(ctx, queries, createEmployeeID, employee.Passport); err != nil {
+		return 0, err
+	}
+	if err = r.recordAssignment(ctx, queries, createEmployeeID, 0, employee.Department.ID); err != nil {
 		return 0, err
 	}
-	return createEmployeeID, nil
+
+	err = r.writeAudit(ctx, queries, auditRecord{
+		entityType: models.AuditEmployee,
+		entityID:   createEmployeeID,
+		operation:  models.AuditCreate,
+		after:      employeeSnapshot(employee),
+	})
+	return createEmployeeID, err
+}
+
+func (r *PostgresRepo) DeleteEmployee(ctx context.Context, id int32, version *int32) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		return r.deleteEmployee(ctx, queries, id, version)
+	})
 }
 
-func (r *PostgresRepo) DeleteEmployee(ctx context.Context, id int32) error {
-	err := r.queries.DeleteEmployee(ctx, id)
+func (r *PostgresRepo) deleteEmployee(ctx context.Context, queries *gen.Queries, id int32, version *int32) error {
+	oldEmployee, err := queries.GetEmployeeForUpdate(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get employee", "error", err)
+		return translateError(err)
+	}
+	if version != nil && *version != oldEmployee.Version {
+		return fmt.Errorf("%w: employee %d has version %d", errs.ErrPreconditionFailed, id, oldEmployee.Version)
+	}
+
+	employee, err := r.lockedEmployee(ctx, oldEmployee)
 	if err != nil {
-		r.log.Error("delete employee", "error", err)
 		return err
 	}
-	return nil
+
+	if _, err = queries.ArchiveEmployee(ctx, id); err != nil {
+		r.log.ErrorContext(ctx, "archive employee", "error", err)
+		return translateError(err)
+	}
+	if err = queries.SetIdentityDocumentsArchived(ctx, gen.SetIdentityDocumentsArchivedParams{
+		EmployeeID: id,
+		Archived:   true,
+	}); err != nil {
+		r.log.ErrorContext(ctx, "archive identity documents", "error", err)
+		return translateError(err)
+	}
+
+	return r.writeAudit(ctx, queries, auditRecord{
+		entityType: models.AuditEmployee,
+		entityID:   id,
+		operation:  models.AuditDelete,
+		before:     employeeSnapshot(employee),
+	})
 }
+func (r *PostgresRepo) RestoreEmployee(ctx context.Context, id int32) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		employee, err := queries.RestoreEmployee(ctx, id)
+		if err != nil {
+			r.log.ErrorContext(ctx, "restore employee", "error", err)
+			return translateError(err)
+		}
+		// fails with a conflict when an active employee took the passport meanwhile
+		if err = queries.SetIdentityDocumentsArchived(ctx, gen.SetIdentityDocumentsArchivedParams{
+			EmployeeID: id,
+			Archived:   false,
+		}); err != nil {
+			r.log.ErrorContext(ctx, "restore identity documents", "error", err)
+			return translateError(err)
+		}
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return err
+		}
 
-func (r *PostgresRepo) GetListCompanyEmployees(ctx context.Context, id int32) ([]*models.Employee, error) {
-	employees, err := r.queries.GetListCompanyEmployee(ctx, id)
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditEmployee,
+			entityID:   id,
+			operation:  models.AuditRestore,
+			after: employeeSnapshot(&models.Employee{
+				Name:      employee.Name,
+				Surname:   employee.Surname,
+				Phone:     employee.Phone,
+				CompanyID: employee.CompanyID,
+				Passport: models.Passport{
+					Type:   employee.PassportType,
+					Number: number,
+				},
+				Department: models.Department{
+					ID: employee.DepartmentID,
+				},
+				ManagerID: int4Ptr(employee.ManagerID),
+			}),
+		})
+	})
+}
+func (r *PostgresRepo) PurgeEmployees(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	rows, err := r.conn(ctx).PurgeEmployees(ctx, pgtype.Timestamptz{Time: deletedBefore, Valid: true})
 	if err != nil {
-		r.log.Error("get employees", "error", err)
-		return nil, err
+		r.log.ErrorContext(ctx, "purge employees", "error", err)
+		return 0, translateError(err)
+	}
+	return rows, nil
+}
+func (r *PostgresRepo) ListArchivedEmployees(ctx context.Context, companyID int32, pagination *models.Pagination) ([]*models.Employee, error) {
+	employees, err := r.conn(ctx).ListArchivedEmployees(ctx, gen.ListArchivedEmployeesParams{
+		CompanyID:  pgtype.Int4{Int32: companyID, Valid: companyID != 0},
+		PageLimit:  pagination.Limit,
+		PageOffset: pagination.Offset,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "get archived employees", "error", err)
+		return nil, translateError(err)
 	}
 
 	listEmployees := make([]*models.Employee, len(employees))
 	for i, employee := range employees {
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return nil, err
+		}
 		listEmployees[i] = &models.Employee{
 			ID:        employee.ID,
 			Name:      employee.Name,
@@ -73,26 +195,101 @@ func (r *PostgresRepo) GetListCompanyEmployees(ctx context.Context, id int32) ([
 			CompanyID: employee.CompanyID,
 			Passport: models.Passport{
 				Type:   employee.PassportType,
-				Number: employee.PassportNumber,
+				Number: number,
 			},
 			Department: models.Department{
+				ID:    employee.ID_2,
 				Name:  employee.Name_2,
 				Phone: employee.Phone_2,
 			},
+			DeletedAt: lo.ToPtr(employee.DeletedAt.Time),
 		}
 	}
 
 	return listEmployees, nil
 }
-func (r *PostgresRepo) GetListDepartmentEmployees(ctx context.Context, idDepartment int32) ([]*models.Employee, error) {
-	employees, err := r.queries.GetListCompanyDepartmentEmployee(ctx, idDepartment)
+
+// ListEmployees reads a page of the employees of params.CompanyID, every sort order has its own query
+// so that the page is read from the index of the order
+func (r *PostgresRepo) ListEmployees(ctx context.Context, params *models.EmployeeListParams, cursor *models.EmployeeCursor) ([]*models.Employee, error) {
+	filter := employeeFilter(params)
+	// the first page starts after the lowest key: ids are positive and no value sorts before ''
+	afterID, afterText := int32(0), ""
+	afterCreatedAt := pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	if cursor != nil {
+		afterID, afterText = cursor.ID, cursor.Text
+		afterCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+	}
+
+	var (
+		employees []gen.ListEmployeesByIDRow
+		err       error
+	)
+	queries := r.conn(ctx)
+	switch params.Sort {
+	case models.SortByName:
+		var rows []gen.ListEmployeesByNameRow
+		rows, err = queries.ListEmployeesByName(ctx, gen.ListEmployeesByNameParams{
+			CompanyID:     params.CompanyID,
+			DepartmentID:  filter.DepartmentID,
+			SurnamePrefix: filter.SurnamePrefix,
+			PassportType:  filter.PassportType,
+			AfterName:     afterText,
+			AfterID:       afterID,
+			PageLimit:     params.Limit,
+		})
+		employees = lo.Map(rows, func(row gen.ListEmployeesByNameRow, _ int) gen.ListEmployeesByIDRow {
+			return gen.ListEmployeesByIDRow(row)
+		})
+	case models.SortBySurname:
+		var rows []gen.ListEmployeesBySurnameRow
+		rows, err = queries.ListEmployeesBySurname(ctx, gen.ListEmployeesBySurnameParams{
+			CompanyID:     params.CompanyID,
+			DepartmentID:  filter.DepartmentID,
+			SurnamePrefix: filter.SurnamePrefix,
+			PassportType:  filter.PassportType,
+			AfterSurname:  afterText,
+			AfterID:       afterID,
+			PageLimit:     params.Limit,
+		})
+		employees = lo.Map(rows, func(row gen.ListEmployeesBySurnameRow, _ int) gen.ListEmployeesByIDRow {
+			return gen.ListEmployeesByIDRow(row)
+		})
+	case models.SortByCreatedAt:
+		var rows []gen.ListEmployeesByCreatedAtRow
+		rows, err = queries.ListEmployeesByCreatedAt(ctx, gen.ListEmployeesByCreatedAtParams{
+			CompanyID:      params.CompanyID,
+			DepartmentID:   filter.DepartmentID,
+			SurnamePrefix:  filter.SurnamePrefix,
+			PassportType:   filter.PassportType,
+			AfterCreatedAt: afterCreatedAt,
+			AfterID:        afterID,
+			PageLimit:      params.Limit,
+		})
+		employees = lo.Map(rows, func(row gen.ListEmployeesByCreatedAtRow, _ int) gen.ListEmployeesByIDRow {
+			return gen.ListEmployeesByIDRow(row)
+		})
+	default:
+		employees, err = queries.ListEmployeesByID(ctx, gen.ListEmployeesByIDParams{
+			CompanyID:     params.CompanyID,
+			DepartmentID:  filter.DepartmentID,
+			SurnamePrefix: filter.SurnamePrefix,
+			PassportType:  filter.PassportType,
+			AfterID:       afterID,
+			PageLimit:     params.Limit,
+		})
+	}
 	if err != nil {
-		r.log.Error("get employees", "error", err)
-		return nil, err
+		r.log.ErrorContext(ctx, "get employees", "error", err)
+		return nil, translateError(err)
 	}
 
 	listEmployees := make([]*models.Employee, len(employees))
 	for i, employee := range employees {
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return nil, err
+		}
 		listEmployees[i] = &models.Employee{
 			ID:        employee.ID,
 			Name:      employee.Name,
@@ -101,72 +298,426 @@ func (r *PostgresRepo) GetListDepartmentEmployees(ctx context.Context, idDepartm
 			CompanyID: employee.CompanyID,
 			Passport: models.Passport{
 				Type:   employee.PassportType,
-				Number: employee.PassportNumber,
+				Number: number,
 			},
 			Department: models.Department{
+				ID:    employee.ID_2,
 				Name:  employee.Name_2,
 				Phone: employee.Phone_2,
 			},
+			CreatedAt: employee.CreatedAt.Time,
 		}
 	}
 
 	return listEmployees, nil
 }
-func (r *PostgresRepo) EditEmployee(ctx context.Context, employee *models.Employee) error {
-	r.log.Debug("edit employee", "employee", employee)
-	oldEmployee, err := r.GetEmployeeByID(ctx, employee.ID)
+func (r *PostgresRepo) CountEmployees(ctx context.Context, params *models.EmployeeListParams) (int64, error) {
+	total, err := r.conn(ctx).CountEmployees(ctx, employeeFilter(params))
 	if err != nil {
-		r.log.Error("get employee", "error", err)
-		return err
+		r.log.ErrorContext(ctx, "count employees", "error", err)
+		return 0, translateError(err)
 	}
-	r.log.Debug("old employee", "old", oldEmployee)
-	err = r.queries.UpdateEmployee(ctx, gen.UpdateEmployeeParams{
-		ID:             employee.ID,
-		Name:           lo.Ternary(employee.Name == "", oldEmployee.Name, employee.Name),
-		Surname:        lo.Ternary(employee.Surname == "", oldEmployee.Surname, employee.Surname),
-		Phone:          lo.Ternary(employee.Phone == "", oldEmployee.Phone, employee.Phone),
-		CompanyID:      lo.Ternary(employee.CompanyID == 0, oldEmployee.CompanyID, employee.CompanyID),
-		DepartmentID:   lo.Ternary(employee.Department.ID == 0, oldEmployee.Department.ID, employee.Department.ID),
-		PassportType:   lo.Ternary(employee.Passport.Type == "", oldEmployee.Passport.Type, employee.Passport.Number),
-		PassportNumber: lo.Ternary(employee.Passport.Number == "", oldEmployee.Passport.Number, employee.Passport.Number),
+
+	return total, nil
+}
+
+func (r *PostgresRepo) SearchEmployees(ctx context.Context, params *models.EmployeeSearchParams) ([]*models.Employee, error) {
+	employees, err := r.conn(ctx).SearchEmployees(ctx, gen.SearchEmployeesParams{
+		Query:     params.Query,
+		CompanyID: pgtype.Int4{Int32: params.CompanyID, Valid: params.CompanyID != 0},
+		Pattern:   likeEscaper.Replace(params.Query),
+		PageLimit: params.Limit,
 	})
 	if err != nil {
-		r.log.Error("update employee", "error", err)
+		r.log.ErrorContext(ctx, "search employees", "error", err)
+		return nil, translateError(err)
+	}
+
+	listEmployees := make([]*models.Employee, len(employees))
+	for i, employee := range employees {
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return nil, err
+		}
+		listEmployees[i] = &models.Employee{
+			ID:        employee.ID,
+			Name:      employee.Name,
+			Surname:   employee.Surname,
+			Phone:     employee.Phone,
+			CompanyID: employee.CompanyID,
+			Passport: models.Passport{
+				Type:   employee.PassportType,
+				Number: number,
+			},
+			Department: models.Department{
+				ID:    employee.ID_2,
+				Name:  employee.Name_2,
+				Phone: employee.Phone_2,
+			},
+		}
+	}
+
+	return listEmployees, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func employeeFilter(params *models.EmployeeListParams) gen.CountEmployeesParams {
+	return gen.CountEmployeesParams{
+		CompanyID:     pgtype.Int4{Int32: params.CompanyID, Valid: params.CompanyID != 0},
+		DepartmentID:  pgtype.Int4{Int32: params.DepartmentID, Valid: params.DepartmentID != 0},
+		SurnamePrefix: pgtype.Text{String: likeEscaper.Replace(params.SurnamePrefix), Valid: params.SurnamePrefix != ""},
+		PassportType:  pgtype.Text{String: params.PassportType, Valid: params.PassportType != ""},
+	}
+}
+
+// EditEmployee locks the employee row, lets update modify the loaded employee
+// and writes the result back in the same transaction.
+func (r *PostgresRepo) EditEmployee(ctx context.Context, id int32, update func(employee *models.Employee) error) (int32, error) {
+	var version int32
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		var err error
+		version, err = r.editEmployee(ctx, queries, id, update)
 		return err
+	})
+	return version, err
+}
+
+func (r *PostgresRepo) editEmployee(ctx context.Context, queries *gen.Queries, id int32, update func(employee *models.Employee) error) (int32, error) {
+	oldEmployee, err := queries.GetEmployeeForUpdate(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get employee", "error", err)
+		return 0, translateError(err)
 	}
 
-	return nil
+	employee, err := r.lockedEmployee(ctx, oldEmployee)
+	if err != nil {
+		return 0, err
+	}
+	passport := employee.Passport
+	before := employeeSnapshot(employee)
+	if err = update(employee); err != nil {
+		return 0, err
+	}
+
+	r.log.DebugContext(ctx, "update employee", "employee_id", id)
+	version, err := queries.UpdateEmployee(ctx, gen.UpdateEmployeeParams{
+		ID:           id,
+		Name:         employee.Name,
+		Surname:      employee.Surname,
+		Phone:        employee.Phone,
+		CompanyID:    employee.CompanyID,
+		DepartmentID: employee.Department.ID,
+		ManagerID:    int4(employee.ManagerID),
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "update employee", "error", err)
+		return 0, translateError(err)
+	}
+	if employee.Passport != passport {
+		if err = r.savePassport(ctx, queries, id, employee.Passport); err != nil {
+			return 0, err
+		}
+	}
+	if employee.Department.ID != oldEmployee.DepartmentID {
+		if err = r.recordAssignment(ctx, queries, id, oldEmployee.DepartmentID, employee.Department.ID); err != nil {
+			return 0, err
+		}
+	}
 
+	err = r.writeAudit(ctx, queries, auditRecord{
+		entityType: models.AuditEmployee,
+		entityID:   id,
+		operation:  models.AuditUpdate,
+		before:     before,
+		after:      employeeSnapshot(employee),
+	})
+	return version, err
+}
+
+// lockedEmployee maps the row locked for update to the model
+func (r *PostgresRepo) lockedEmployee(ctx context.Context, employee gen.GetEmployeeForUpdateRow) (*models.Employee, error) {
+	number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Employee{
+		ID:        employee.ID,
+		Name:      employee.Name,
+		Surname:   employee.Surname,
+		Phone:     employee.Phone,
+		CompanyID: employee.CompanyID,
+		Passport: models.Passport{
+			Type:   employee.PassportType,
+			Number: number,
+		},
+		Department: models.Department{
+			ID: employee.DepartmentID,
+		},
+		ManagerID: int4Ptr(employee.ManagerID),
+		Version:   employee.Version,
+	}, nil
 }
 func (r *PostgresRepo) CreateCompany(ctx context.Context, name string) (int32, error) {
-	companyID, err := r.queries.CreateCompany(ctx, name)
+	var companyID int32
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		var err error
+		companyID, err = queries.CreateCompany(ctx, name)
+		if err != nil {
+			r.log.ErrorContext(ctx, "create company", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditCompany,
+			entityID:   companyID,
+			operation:  models.AuditCreate,
+			after:      companySnapshot(&models.Company{Name: name}),
+		})
+	})
+	return companyID, err
+}
+func (r *PostgresRepo) GetCompanies(ctx context.Context, pagination *models.Pagination) ([]*models.Company, error) {
+	companies, err := r.conn(ctx).GetCompanies(ctx, gen.GetCompaniesParams{
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+	})
 	if err != nil {
-		r.log.Error("create company", "error", err)
-		return 0, err
+		r.log.ErrorContext(ctx, "get companies", "error", err)
+		return nil, translateError(err)
 	}
 
-	return companyID, nil
+	listCompanies := make([]*models.Company, len(companies))
+	for i, company := range companies {
+		listCompanies[i] = &models.Company{
+			ID:   company.ID,
+			Name: company.Name,
+		}
+	}
+
+	return listCompanies, nil
+}
+func (r *PostgresRepo) GetCompanyByID(ctx context.Context, id int32) (*models.Company, error) {
+	company, err := r.conn(ctx).GetCompanyByID(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get company", "error", err)
+		return nil, translateError(err)
+	}
+
+	return &models.Company{
+		ID:   company.ID,
+		Name: company.Name,
+	}, nil
+}
+func (r *PostgresRepo) EditCompany(ctx context.Context, company *models.Company) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		oldCompany, err := queries.GetCompanyForUpdate(ctx, company.ID)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get company", "error", err)
+			return translateError(err)
+		}
+
+		_, err = queries.UpdateCompany(ctx, gen.UpdateCompanyParams{
+			ID:   company.ID,
+			Name: company.Name,
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "update company", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditCompany,
+			entityID:   company.ID,
+			operation:  models.AuditUpdate,
+			before:     companySnapshot(&models.Company{Name: oldCompany.Name}),
+			after:      companySnapshot(company),
+		})
+	})
+}
+func (r *PostgresRepo) DeleteCompany(ctx context.Context, id int32) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		oldCompany, err := queries.GetCompanyForUpdate(ctx, id)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get company", "error", err)
+			return translateError(err)
+		}
+		// employees are archived and audited one by one, the company can go once they are purged
+		employees, err := queries.CountCompanyEmployees(ctx, id)
+		if err != nil {
+			r.log.ErrorContext(ctx, "count company employees", "error", err)
+			return translateError(err)
+		}
+		if employees > 0 {
+			return fmt.Errorf("%w: company %d has %d employees", errs.ErrConflict, id, employees)
+		}
+
+		if _, err = queries.DeleteCompany(ctx, id); err != nil {
+			r.log.ErrorContext(ctx, "delete company", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditCompany,
+			entityID:   id,
+			operation:  models.AuditDelete,
+			before:     companySnapshot(&models.Company{Name: oldCompany.Name}),
+		})
+	})
 }
 func (r *PostgresRepo) CreateDepartment(ctx context.Context, department *models.Department) (int32, error) {
-	departmentID, err := r.queries.CreateDepartment(ctx, gen.CreateDepartmentParams{
+	var departmentID int32
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		var err error
+		departmentID, err = queries.CreateDepartment(ctx, gen.CreateDepartmentParams{
+			Name:               department.Name,
+			Phone:              department.Phone,
+			CompanyID:          department.CompanyID,
+			ParentDepartmentID: int4(department.ParentID),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// ON CONFLICT DO NOTHING returns no rows for a duplicate name
+			return fmt.Errorf("%w: department %q", errs.ErrConflict, department.Name)
+		}
+		if err != nil {
+			r.log.ErrorContext(ctx, "create department", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditDepartment,
+			entityID:   departmentID,
+			operation:  models.AuditCreate,
+			after:      departmentSnapshot(department),
+		})
+	})
+	return departmentID, err
+}
+func (r *PostgresRepo) GetDepartmentByID(ctx context.Context, id int32) (*models.Department, error) {
+	department, err := r.conn(ctx).GetDepartmentByID(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get department", "error", err)
+		return nil, translateError(err)
+	}
+
+	return &models.Department{
+		ID:        department.ID,
 		Name:      department.Name,
 		Phone:     department.Phone,
 		CompanyID: department.CompanyID,
-	})
+		ParentID:  int4Ptr(department.ParentDepartmentID),
+	}, nil
+}
+func (r *PostgresRepo) GetCompanyDepartments(ctx context.Context, companyID int32) ([]*models.Department, error) {
+	departments, err := r.conn(ctx).GetCompanyDepartments(ctx, companyID)
 	if err != nil {
-		r.log.Error("create department", "error", err)
-		return 0, err
+		r.log.ErrorContext(ctx, "get departments", "error", err)
+		return nil, translateError(err)
 	}
 
-	return departmentID, nil
+	listDepartments := make([]*models.Department, len(departments))
+	for i, department := range departments {
+		listDepartments[i] = &models.Department{
+			ID:        department.ID,
+			Name:      department.Name,
+			Phone:     department.Phone,
+			CompanyID: department.CompanyID,
+			ParentID:  int4Ptr(department.ParentDepartmentID),
+		}
+	}
+
+	return listDepartments, nil
+}
+func (r *PostgresRepo) EditDepartment(ctx context.Context, department *models.Department) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		row, err := queries.GetDepartmentForUpdate(ctx, department.ID)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get department", "error", err)
+			return translateError(err)
+		}
+		oldDepartment := &models.Department{
+			ID:        row.ID,
+			Name:      row.Name,
+			Phone:     row.Phone,
+			CompanyID: row.CompanyID,
+			ParentID:  int4Ptr(row.ParentDepartmentID),
+		}
+		newDepartment := &models.Department{
+			ID:        row.ID,
+			Name:      lo.Ternary(department.Name == "", oldDepartment.Name, department.Name),
+			Phone:     lo.Ternary(department.Phone == "", oldDepartment.Phone, department.Phone),
+			CompanyID: row.CompanyID,
+			ParentID:  lo.Ternary(department.ParentID == nil, oldDepartment.ParentID, department.ParentID),
+		}
+		if lo.FromPtr(newDepartment.ParentID) == 0 {
+			newDepartment.ParentID = nil
+		}
+
+		_, err = queries.UpdateDepartment(ctx, gen.UpdateDepartmentParams{
+			ID:                 newDepartment.ID,
+			Name:               newDepartment.Name,
+			Phone:              newDepartment.Phone,
+			ParentDepartmentID: int4(newDepartment.ParentID),
+		})
+		if err != nil {
+			r.log.ErrorContext(ctx, "update department", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditDepartment,
+			entityID:   department.ID,
+			operation:  models.AuditUpdate,
+			before:     departmentSnapshot(oldDepartment),
+			after:      departmentSnapshot(newDepartment),
+		})
+	})
+}
+func (r *PostgresRepo) DeleteDepartment(ctx context.Context, id int32) error {
+	return r.inTx(ctx, func(queries *gen.Queries) error {
+		row, err := queries.GetDepartmentForUpdate(ctx, id)
+		if err != nil {
+			r.log.ErrorContext(ctx, "get department", "error", err)
+			return translateError(err)
+		}
+		employees, err := queries.CountDepartmentEmployees(ctx, id)
+		if err != nil {
+			r.log.ErrorContext(ctx, "count department employees", "error", err)
+			return translateError(err)
+		}
+		if employees > 0 {
+			return fmt.Errorf("%w: department %d has %d employees", errs.ErrConflict, id, employees)
+		}
+
+		if _, err = queries.DeleteDepartment(ctx, id); err != nil {
+			r.log.ErrorContext(ctx, "delete department", "error", err)
+			return translateError(err)
+		}
+
+		return r.writeAudit(ctx, queries, auditRecord{
+			entityType: models.AuditDepartment,
+			entityID:   id,
+			operation:  models.AuditDelete,
+			before: departmentSnapshot(&models.Department{
+				Name:      row.Name,
+				Phone:     row.Phone,
+				CompanyID: row.CompanyID,
+				ParentID:  int4Ptr(row.ParentDepartmentID),
+			}),
+		})
+	})
 }
 func (r *PostgresRepo) GetEmployeeByID(ctx context.Context, id int32) (*models.Employee, error) {
-	employee, err := r.queries.GetEmployeeByID(ctx, id)
+	employee, err := r.conn(ctx).GetEmployeeByID(ctx, id)
 	if err != nil {
-		r.log.Error("get employee", "error", err)
-		return nil, err
+		r.log.ErrorContext(ctx, "get employee", "error", err)
+		return nil, translateError(err)
 	}
 
+	number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+	if err != nil {
+		return nil, err
+	}
 	modelEmployee := &models.Employee{
 		ID:        employee.ID,
 		Name:      employee.Name,
@@ -175,12 +726,135 @@ func (r *PostgresRepo) GetEmployeeByID(ctx context.Context, id int32) (*models.E
 		CompanyID: employee.CompanyID,
 		Passport: models.Passport{
 			Type:   employee.PassportType,
-			Number: employee.PassportNumber,
+			Number: number,
 		},
 		Department: models.Department{
-			ID: employee.DepartmentID,
+			ID:    employee.DepartmentID,
+			Name:  employee.Name_2,
+			Phone: employee.Phone_2,
 		},
+		ManagerID: int4Ptr(employee.ManagerID),
+		Version:   employee.Version,
 	}
 
 	return modelEmployee, nil
 }
+func (r *PostgresRepo) GetEmployeeScope(ctx context.Context, id int32) (*models.EmployeeScope, error) {
+	scope, err := r.conn(ctx).GetEmployeeScope(ctx, id)
+	if err != nil {
+		r.log.ErrorContext(ctx, "get employee scope", "error", err)
+		return nil, translateError(err)
+	}
+	return &models.EmployeeScope{
+		CompanyID:    scope.CompanyID,
+		DepartmentID: scope.DepartmentID,
+	}, nil
+}
+func (r *PostgresRepo) FindEmployeesByIdentifiers(ctx context.Context, phones, passportNumbers []string) ([]*models.EmployeeIdentifiers, error) {
+	// sealed numbers are matched on the blind index, numbers not sealed yet on the plaintext
+	passportHashes := make([][]byte, len(passportNumbers))
+	for i, number := range passportNumbers {
+		passportHashes[i] = r.vault.BlindIndex(number)
+	}
+	employees, err := r.conn(ctx).FindEmployeesByIdentifiers(ctx, gen.FindEmployeesByIdentifiersParams{
+		Phones:          phones,
+		PassportHashes:  passportHashes,
+		PassportNumbers: passportNumbers,
+	})
+	if err != nil {
+		r.log.ErrorContext(ctx, "find employees by identifiers", "error", err)
+		return nil, translateError(err)
+	}
+
+	identifiers := make([]*models.EmployeeIdentifiers, len(employees))
+	for i, employee := range employees {
+		number, err := r.openNumber(ctx, employee.PassportNumber, employee.PassportKeyID, employee.PassportCiphertext)
+		if err != nil {
+			return nil, err
+		}
+		identifiers[i] = &models.EmployeeIdentifiers{
+			ID:             employee.ID,
+			Phone:          employee.Phone,
+			PassportNumber: number,
+		}
+	}
+	return identifiers, nil
+}
+
+// ImportEmployees copies all employees in one transaction and returns their ids in the same order
+func (r *PostgresRepo) ImportEmployees(ctx context.Context, employees []*models.Employee) ([]int32, error) {
+	ids := make([]int32, len(employees))
+	err := r.inTx(ctx, func(queries *gen.Queries) error {
+		params := make([]gen.CopyEmployeesParams, len(employees))
+		phones := make([]string, len(employees))
+		for i, employee := range employees {
+			params[i] = gen.CopyEmployeesParams{
+				Name:         employee.Name,
+				Surname:      employee.Surname,
+				Phone:        employee.Phone,
+				CompanyID:    employee.CompanyID,
+				DepartmentID: employee.Department.ID,
+			}
+			phones[i] = employee.Phone
+		}
+		if _, err := queries.CopyEmployees(ctx, params); err != nil {
+			r.log.ErrorContext(ctx, "copy employees", "error", err)
+			return translateError(err)
+		}
+
+		// COPY does not return ids, phones are unique among active employees
+		created, err := queries.FindEmployeesByIdentifiers(ctx, gen.FindEmployeesByIdentifiersParams{Phones: phones})
+		if err != nil {
+			r.log.ErrorContext(ctx, "find imported employees", "error", err)
+			return translateError(err)
+		}
+		idByPhone := make(map[string]int32, len(created))
+		for _, employee := range created {
+			idByPhone[employee.Phone] = employee.ID
+		}
+
+		records := make([]auditRecord, len(employees))
+		documents := make([]gen.CopyIdentityDocumentsParams, len(employees))
+		for i, employee := range employees {
+			ids[i] = idByPhone[employee.Phone]
+			number, err := r.sealNumber(ctx, employee.Passport.Number)
+			if err != nil {
+				return err
+			}
+			documents[i] = gen.CopyIdentityDocumentsParams{
+				EmployeeID:       ids[i],
+				Type:             employee.Passport.Type,
+				NumberHash:       number.hash,
+				NumberKeyID:      number.keyID,
+				NumberCiphertext: number.ciphertext,
+				IsPrimary:        true,
+			}
+			records[i] = auditRecord{
+				entityType: models.AuditEmployee,
+				entityID:   ids[i],
+				operation:  models.AuditCreate,
+				after:      employeeSnapshot(employee),
+			}
+		}
+		if _, err = queries.CopyIdentityDocuments(ctx, documents); err != nil {
+			r.log.ErrorContext(ctx, "copy identity documents", "error", err)
+			return translateError(err)
+		}
+		if err = r.copyAudit(ctx, queries, records); err != nil {
+			return err
+		}
+
+		if err = queries.CreateAppliedAssignments(ctx, gen.CreateAppliedAssignmentsParams{
+			Actor:       audit.ActorFromContext(ctx),
+			EmployeeIds: ids,
+		}); err != nil {
+			r.log.ErrorContext(ctx, "create assignments", "error", err)
+			return translateError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
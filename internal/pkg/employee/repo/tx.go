@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"employees/gen"
+	"employees/internal/pkg/db"
+	"github.com/jackc/pgx/v5"
+)
+
+// conn returns queries bound to the transaction in ctx, or to the pool outside of one
+func (r *PostgresRepo) conn(ctx context.Context) *gen.Queries {
+	if tx, ok := db.TxFromContext(ctx); ok {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// begin starts a transaction, or a savepoint when ctx already carries a transaction from db.TxManager
+func (r *PostgresRepo) begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := db.TxFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
+	return r.db.Begin(ctx)
+}
+
+// inTx runs fn with queries bound to one transaction and commits when fn succeeds
+func (r *PostgresRepo) inTx(ctx context.Context, fn func(queries *gen.Queries) error) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		r.log.ErrorContext(ctx, "begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err = fn(r.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		r.log.ErrorContext(ctx, "commit transaction", "error", err)
+		return err
+	}
+	return nil
+}
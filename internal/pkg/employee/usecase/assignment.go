@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	"employees/internal/pkg/errs"
+	"errors"
+	"time"
+)
+
+// dueTransfersBatch limits how many scheduled transfers one ApplyDueTransfers call applies
+const dueTransfersBatch = 100
+
+// TransferEmployee moves the employee to another department of the same company,
+// today or later. Transfers effective today are applied at once, later ones are scheduled.
+func (uc *Usecase) TransferEmployee(ctx context.Context, transfer *models.Transfer) (*models.Assignment, error) {
+	now := time.Now().UTC()
+	today := now.Format(models.DateLayout)
+	if transfer.EffectiveDate == "" {
+		transfer.EffectiveDate = today
+	}
+	if err := validateTransfer(transfer, today); err != nil {
+		return nil, err
+	}
+	// dates in DateLayout compare as strings
+	due := transfer.EffectiveDate <= today
+
+	var assignment *models.Assignment
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		employee, err := uc.repo.GetEmployeeByID(ctx, transfer.EmployeeID)
+		if err != nil {
+			return err
+		}
+		target := auth.Resource{CompanyID: employee.CompanyID, DepartmentID: transfer.DepartmentID}
+		if err = auth.Authorize(ctx, auth.ActionWriteEmployees, employeeResource(employee)); err != nil {
+			return err
+		}
+		if err = auth.Authorize(ctx, auth.ActionWriteEmployees, target); err != nil {
+			return err
+		}
+		if err = uc.checkDepartmentCompany(ctx, transfer.DepartmentID, employee.CompanyID); err != nil {
+			return err
+		}
+		if due && employee.Department.ID == transfer.DepartmentID {
+			return &errs.ValidationError{Fields: []errs.FieldError{{Field: "department_id", Message: "employee is already in this department"}}}
+		}
+
+		assignment, err = uc.repo.CreateAssignment(ctx, &models.Assignment{
+			EmployeeID:       transfer.EmployeeID,
+			FromDepartmentID: &employee.Department.ID,
+			DepartmentID:     transfer.DepartmentID,
+			EffectiveDate:    transfer.EffectiveDate,
+			Reason:           transfer.Reason,
+		})
+		if err != nil || !due {
+			return err
+		}
+
+		if err = uc.repo.ApplyAssignment(ctx, assignment.ID); err != nil {
+			return err
+		}
+		assignment.Status = models.AssignmentApplied
+		assignment.AppliedAt = &now
+		return nil
+	})
+	return assignment, err
+}
+
+func (uc *Usecase) GetEmployeeAssignments(ctx context.Context, id int32) ([]*models.Assignment, error) {
+	employee, err := uc.repo.GetEmployeeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = auth.Authorize(ctx, auth.ActionReadEmployees, employeeResource(employee)); err != nil {
+		return nil, err
+	}
+	assignments, err := uc.repo.ListEmployeeAssignments(ctx, id)
+	return assignments, err
+}
+
+// ApplyDueTransfers applies scheduled transfers effective on or before the date of now.
+// Transfers that can no longer happen, because the employee was archived or moved to
+// another company, are cancelled.
+func (uc *Usecase) ApplyDueTransfers(ctx context.Context, now time.Time) (int, error) {
+	if err := auth.Authorize(ctx, auth.ActionAdminister, auth.Resource{}); err != nil {
+		return 0, err
+	}
+	var applied int
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		applied = 0
+		assignments, err := uc.repo.ListDueAssignments(ctx, now.UTC(), dueTransfersBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, assignment := range assignments {
+			err = uc.repo.ApplyAssignment(ctx, assignment.ID)
+			switch {
+			case err == nil:
+				applied++
+			case errors.Is(err, errs.ErrNotFound), errors.Is(err, errs.ErrDepartmentCompanyMismatch):
+				uc.log.WarnContext(ctx, "cancel transfer", "assignment_id", assignment.ID, "employee_id", assignment.EmployeeID, "error", err.Error())
+				if err = uc.repo.CancelAssignment(ctx, assignment.ID); err != nil {
+					return err
+				}
+			default:
+				return err
+			}
+		}
+		return nil
+	})
+	return applied, err
+}
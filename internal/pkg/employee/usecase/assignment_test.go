@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestUsecase_TransferEmployee(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockRepository)
+
+	today := time.Now().UTC().Format(models.DateLayout)
+	employee := &models.Employee{ID: 5, CompanyID: 1, Department: models.Department{ID: 2}}
+	expectEmployee := func(m *mockEmployee.MockRepository) {
+		m.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(employee, nil)
+		m.EXPECT().GetDepartmentByID(gomock.Any(), int32(3)).Return(&models.Department{ID: 3, CompanyID: 1}, nil)
+	}
+
+	testTable := []struct {
+		name           string
+		transfer       *models.Transfer
+		mockBehavior   mockBehavior
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name:     "applied today",
+			transfer: &models.Transfer{EmployeeID: 5, DepartmentID: 3, Reason: "reorg"},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				expectEmployee(m)
+				m.EXPECT().CreateAssignment(gomock.Any(), &models.Assignment{
+					EmployeeID:       5,
+					FromDepartmentID: &employee.Department.ID,
+					DepartmentID:     3,
+					EffectiveDate:    today,
+					Reason:           "reorg",
+				}).Return(&models.Assignment{ID: 7, Status: models.AssignmentScheduled}, nil)
+				m.EXPECT().ApplyAssignment(gomock.Any(), int64(7)).Return(nil)
+			},
+			expectedStatus: models.AssignmentApplied,
+		},
+		{
+			name:     "scheduled",
+			transfer: &models.Transfer{EmployeeID: 5, DepartmentID: 3, EffectiveDate: "2999-01-01"},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				expectEmployee(m)
+				m.EXPECT().CreateAssignment(gomock.Any(), gomock.Any()).
+					Return(&models.Assignment{ID: 7, Status: models.AssignmentScheduled}, nil)
+			},
+			expectedStatus: models.AssignmentScheduled,
+		},
+		{
+			name:     "already in department",
+			transfer: &models.Transfer{EmployeeID: 5, DepartmentID: 2},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(employee, nil)
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(&models.Department{ID: 2, CompanyID: 1}, nil)
+			},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name:     "department of another company",
+			transfer: &models.Transfer{EmployeeID: 5, DepartmentID: 3},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(employee, nil)
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(3)).Return(&models.Department{ID: 3, CompanyID: 9}, nil)
+			},
+			expectedError: errs.ErrDepartmentCompanyMismatch,
+		},
+		{
+			name:          "date in the past",
+			transfer:      &models.Transfer{EmployeeID: 5, DepartmentID: 3, EffectiveDate: "2000-01-01"},
+			mockBehavior:  func(m *mockEmployee.MockRepository) {},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name:          "malformed date",
+			transfer:      &models.Transfer{EmployeeID: 5, DepartmentID: 3, EffectiveDate: "01.01.2999"},
+			mockBehavior:  func(m *mockEmployee.MockRepository) {},
+			expectedError: errs.ErrValidation,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			tt.mockBehavior(mockRepo)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			assignment, err := uc.TransferEmployee(context.Background(), tt.transfer)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(7), assignment.ID)
+			assert.Equal(t, tt.expectedStatus, assignment.Status)
+		})
+	}
+}
+
+func TestUsecase_ApplyDueTransfers(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("apply and cancel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().ListDueAssignments(gomock.Any(), now, int32(dueTransfersBatch)).Return([]*models.Assignment{
+			{ID: 1, EmployeeID: 5},
+			{ID: 2, EmployeeID: 6},
+			{ID: 3, EmployeeID: 7},
+		}, nil)
+		mockRepo.EXPECT().ApplyAssignment(gomock.Any(), int64(1)).Return(nil)
+		mockRepo.EXPECT().ApplyAssignment(gomock.Any(), int64(2)).Return(errs.ErrNotFound)
+		mockRepo.EXPECT().CancelAssignment(gomock.Any(), int64(2)).Return(nil)
+		mockRepo.EXPECT().ApplyAssignment(gomock.Any(), int64(3)).Return(nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		applied, err := uc.ApplyDueTransfers(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, applied)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().ListDueAssignments(gomock.Any(), now, int32(dueTransfersBatch)).
+			Return([]*models.Assignment{{ID: 1, EmployeeID: 5}}, nil)
+		mockRepo.EXPECT().ApplyAssignment(gomock.Any(), int64(1)).Return(fmt.Errorf("connection reset"))
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.ApplyDueTransfers(context.Background(), now)
+		assert.Error(t, err)
+	})
+}
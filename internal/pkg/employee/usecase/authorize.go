@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/pii"
+	"errors"
+	"fmt"
+)
+
+// authorizeEmployee checks the action on an employee, archived ones included.
+// The scope of the employee is only looked up for callers bound to a company, they get the same
+// error for an employee that does not exist and for one outside their scope.
+func (uc *Usecase) authorizeEmployee(ctx context.Context, action auth.Action, id int32) error {
+	if !auth.Restricted(ctx) {
+		return nil
+	}
+	scope, err := uc.repo.GetEmployeeScope(ctx, id)
+	if errors.Is(err, errs.ErrNotFound) {
+		return employeeOutOfScope(id)
+	}
+	if err != nil {
+		return err
+	}
+	if auth.Authorize(ctx, action, auth.Resource{CompanyID: scope.CompanyID, DepartmentID: scope.DepartmentID}) != nil {
+		return employeeOutOfScope(id)
+	}
+	return nil
+}
+
+func employeeOutOfScope(id int32) error {
+	return &errs.ForbiddenError{Reason: fmt.Sprintf("employee %d is outside the scope of the caller", id)}
+}
+
+// authorizeDepartment checks the action on the department, grants over the whole company
+// cover every department of the company the department belongs to. Like authorizeEmployee
+// it does not tell callers bound to a company whether a department outside their scope exists.
+func (uc *Usecase) authorizeDepartment(ctx context.Context, action auth.Action, id int32) error {
+	if !auth.Restricted(ctx) {
+		return nil
+	}
+	department, err := uc.repo.GetDepartmentByID(ctx, id)
+	if errors.Is(err, errs.ErrNotFound) {
+		return departmentOutOfScope(id)
+	}
+	if err != nil {
+		return err
+	}
+	if auth.Authorize(ctx, action, auth.Resource{CompanyID: department.CompanyID, DepartmentID: department.ID}) != nil {
+		return departmentOutOfScope(id)
+	}
+	return nil
+}
+
+func departmentOutOfScope(id int32) error {
+	return &errs.ForbiddenError{Reason: fmt.Sprintf("department %d is outside the scope of the caller", id)}
+}
+
+func employeeResource(employee *models.Employee) auth.Resource {
+	return auth.Resource{CompanyID: employee.CompanyID, DepartmentID: employee.Department.ID}
+}
+
+// readableEmployees drops the employees the caller may not read
+func readableEmployees(ctx context.Context, employees []*models.Employee) []*models.Employee {
+	readable := make([]*models.Employee, 0, len(employees))
+	for _, employee := range employees {
+		if auth.Authorize(ctx, auth.ActionReadEmployees, employeeResource(employee)) == nil {
+			readable = append(readable, employee)
+		}
+	}
+	return readable
+}
+
+// maskEmployees masks the passport numbers of the employees the caller may not read them of
+func maskEmployees(ctx context.Context, employees ...*models.Employee) {
+	for _, employee := range employees {
+		if auth.Authorize(ctx, auth.ActionReadPII, employeeResource(employee)) != nil {
+			employee.Passport.Number = pii.Mask(employee.Passport.Number)
+		}
+	}
+}
+
+// maskDocuments masks the numbers of the documents of the employee unless the caller may read them
+func maskDocuments(ctx context.Context, employee *models.Employee, documents ...*models.IdentityDocument) {
+	if auth.Authorize(ctx, auth.ActionReadPII, employeeResource(employee)) == nil {
+		return
+	}
+	for _, document := range documents {
+		document.Number = pii.Mask(document.Number)
+	}
+}
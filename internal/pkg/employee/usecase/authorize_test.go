@@ -0,0 +1,387 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func callerContext(role string, companyID, departmentID int32) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{
+		Subject:      "alice",
+		Method:       auth.MethodJWT,
+		Role:         role,
+		CompanyID:    companyID,
+		DepartmentID: departmentID,
+	})
+}
+
+func TestUsecase_GetEmployeeAuthorization(t *testing.T) {
+	employee := &models.Employee{ID: 5, CompanyID: 1, Department: models.Department{ID: 10}}
+	scope := &models.EmployeeScope{CompanyID: 1, DepartmentID: 10}
+
+	testTable := []struct {
+		name          string
+		ctx           context.Context
+		mockBehavior  func(m *mockEmployee.MockRepository)
+		expectedError error
+	}{
+		{
+			name: "hr manager of the company",
+			ctx:  callerContext(auth.RoleHRManager, 1, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(scope, nil)
+				m.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(employee, nil)
+			},
+		},
+		{
+			name: "head of the department",
+			ctx:  callerContext(auth.RoleDepartmentHead, 1, 10),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(scope, nil)
+				m.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(employee, nil)
+			},
+		},
+		{
+			name: "head of another department",
+			ctx:  callerContext(auth.RoleDepartmentHead, 1, 11),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(scope, nil)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "viewer of another company",
+			ctx:  callerContext(auth.RoleViewer, 2, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(scope, nil)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "viewer probing a missing employee",
+			ctx:  callerContext(auth.RoleViewer, 2, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(nil, errs.ErrNotFound)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "admin",
+			ctx:  callerContext(auth.RoleAdmin, 0, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(employee, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			testCase.mockBehavior(mockRepo)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			got, err := uc.GetEmployee(testCase.ctx, 5)
+			assert.ErrorIs(t, err, testCase.expectedError)
+			if testCase.expectedError != nil {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestUsecase_EditEmployeeAuthorization(t *testing.T) {
+	testTable := []struct {
+		name          string
+		ctx           context.Context
+		patch         *models.UpdateEmployee
+		mockBehavior  func(m *mockEmployee.MockRepository)
+		expectedError error
+	}{
+		{
+			name:  "head renames an employee of the department",
+			ctx:   callerContext(auth.RoleDepartmentHead, 1, 10),
+			patch: &models.UpdateEmployee{ID: 5, Name: lo.ToPtr("Olga")},
+		},
+		{
+			name:          "head moves an employee out of the department",
+			ctx:           callerContext(auth.RoleDepartmentHead, 1, 10),
+			patch:         &models.UpdateEmployee{ID: 5, DepartmentID: lo.ToPtr(int32(11))},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name:  "hr manager moves an employee to another department",
+			ctx:   callerContext(auth.RoleHRManager, 1, 0),
+			patch: &models.UpdateEmployee{ID: 5, DepartmentID: lo.ToPtr(int32(11))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(11)).Return(&models.Department{ID: 11, CompanyID: 1}, nil)
+			},
+		},
+		{
+			name:          "hr manager moves an employee to another company",
+			ctx:           callerContext(auth.RoleHRManager, 1, 0),
+			patch:         &models.UpdateEmployee{ID: 5, CompanyID: lo.ToPtr(int32(2)), DepartmentID: lo.ToPtr(int32(20))},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name:          "viewer",
+			ctx:           callerContext(auth.RoleViewer, 1, 0),
+			patch:         &models.UpdateEmployee{ID: 5, Name: lo.ToPtr("Olga")},
+			expectedError: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			if testCase.mockBehavior != nil {
+				testCase.mockBehavior(mockRepo)
+			}
+			mockRepo.EXPECT().EditEmployee(gomock.Any(), int32(5), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int32, update func(employee *models.Employee) error) (int32, error) {
+					employee := &models.Employee{ID: 5, Name: "Anna", CompanyID: 1, Department: models.Department{ID: 10}, Version: 1}
+					if err := update(employee); err != nil {
+						return 0, err
+					}
+					return employee.Version + 1, nil
+				})
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			_, err := uc.EditEmployee(testCase.ctx, testCase.patch)
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestUsecase_DeleteEmployeeAuthorization(t *testing.T) {
+	t.Run("archived scope is looked up for restricted callers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(&models.EmployeeScope{CompanyID: 2, DepartmentID: 20}, nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		err := uc.DeleteEmployee(callerContext(auth.RoleHRManager, 1, 0), 5, nil)
+		assert.ErrorIs(t, err, errs.ErrForbidden)
+	})
+
+	t.Run("admin skips the lookup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().DeleteEmployee(gomock.Any(), int32(5), nil).Return(nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		err := uc.DeleteEmployee(callerContext(auth.RoleAdmin, 0, 0), 5, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUsecase_CompanyAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockEmployee.NewMockRepository(ctrl)
+	uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+	ctx := callerContext(auth.RoleHRManager, 1, 0)
+
+	_, err := uc.CreateCompany(ctx, "Acme")
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	_, err = uc.GetCompanies(ctx, &models.Pagination{Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	_, err = uc.GetListCompanyEmployees(ctx, &models.EmployeeListParams{CompanyID: 2, Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	_, err = uc.SearchEmployees(ctx, &models.EmployeeSearchParams{Query: "ivan", Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+}
+
+func TestUsecase_GetListDepartmentCompanyEmployeesAuthorization(t *testing.T) {
+	department := &models.Department{ID: 10, CompanyID: 1}
+	listed := &models.EmployeeListParams{CompanyID: 1, DepartmentID: 10, Limit: 11, Sort: models.SortByID}
+
+	testTable := []struct {
+		name          string
+		ctx           context.Context
+		mockBehavior  func(m *mockEmployee.MockRepository)
+		expectedError error
+	}{
+		{
+			name: "head of the department",
+			ctx:  callerContext(auth.RoleDepartmentHead, 1, 10),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(department, nil).Times(2)
+				m.EXPECT().ListEmployees(gomock.Any(), listed, nil).Return([]*models.Employee{}, nil)
+				m.EXPECT().CountEmployees(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "viewer of the company",
+			ctx:  callerContext(auth.RoleViewer, 1, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(department, nil).Times(2)
+				m.EXPECT().ListEmployees(gomock.Any(), listed, nil).Return([]*models.Employee{}, nil)
+				m.EXPECT().CountEmployees(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "head of another department",
+			ctx:  callerContext(auth.RoleDepartmentHead, 1, 11),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(department, nil)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "viewer of another company",
+			ctx:  callerContext(auth.RoleViewer, 2, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(department, nil)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			testCase.mockBehavior(mockRepo)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			_, err := uc.GetListDepartmentCompanyEmployees(testCase.ctx, &models.EmployeeListParams{DepartmentID: 10, Limit: 10})
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestUsecase_GetDepartmentAuthorization(t *testing.T) {
+	department := &models.Department{ID: 10, Name: "dev", CompanyID: 1}
+
+	testTable := []struct {
+		name          string
+		ctx           context.Context
+		mockBehavior  func(m *mockEmployee.MockRepository)
+		expectedError error
+	}{
+		{
+			name: "viewer of the company",
+			ctx:  callerContext(auth.RoleViewer, 1, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(department, nil).Times(2)
+			},
+		},
+		{
+			name: "viewer of another company",
+			ctx:  callerContext(auth.RoleViewer, 2, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(department, nil)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "viewer probing a missing department",
+			ctx:  callerContext(auth.RoleViewer, 2, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(nil, errs.ErrNotFound)
+			},
+			expectedError: errs.ErrForbidden,
+		},
+		{
+			name: "admin gets a missing department",
+			ctx:  callerContext(auth.RoleAdmin, 0, 0),
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(10)).Return(nil, errs.ErrNotFound)
+			},
+			expectedError: errs.ErrNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			testCase.mockBehavior(mockRepo)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			got, err := uc.GetDepartment(testCase.ctx, 10)
+			assert.ErrorIs(t, err, testCase.expectedError)
+			if testCase.expectedError != nil {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestUsecase_GetEmployeeMasksPassport(t *testing.T) {
+	testTable := []struct {
+		name           string
+		ctx            context.Context
+		expectedNumber string
+	}{
+		{name: "hr manager", ctx: callerContext(auth.RoleHRManager, 1, 0), expectedNumber: "4510 123456"},
+		{name: "admin", ctx: callerContext(auth.RoleAdmin, 0, 0), expectedNumber: "4510 123456"},
+		{name: "without identity", ctx: context.Background(), expectedNumber: "4510 123456"},
+		{name: "head of the department", ctx: callerContext(auth.RoleDepartmentHead, 1, 10), expectedNumber: "****3456"},
+		{name: "viewer", ctx: callerContext(auth.RoleViewer, 1, 0), expectedNumber: "****3456"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			mockRepo.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).
+				Return(&models.EmployeeScope{CompanyID: 1, DepartmentID: 10}, nil).AnyTimes()
+			mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).Return(&models.Employee{
+				ID:         5,
+				CompanyID:  1,
+				Department: models.Department{ID: 10},
+				Passport:   models.Passport{Type: "РФ", Number: "4510 123456"},
+			}, nil)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			got, err := uc.GetEmployee(testCase.ctx, 5)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedNumber, got.Passport.Number)
+		})
+	}
+}
+
+func TestUsecase_GetEmployeeDocumentsMasksNumbers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockEmployee.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetEmployeeScope(gomock.Any(), int32(5)).Return(&models.EmployeeScope{CompanyID: 1, DepartmentID: 10}, nil)
+	mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(5)).
+		Return(&models.Employee{ID: 5, CompanyID: 1, Department: models.Department{ID: 10}}, nil)
+	mockRepo.EXPECT().ListIdentityDocuments(gomock.Any(), int32(5)).Return([]*models.IdentityDocument{
+		{ID: 1, EmployeeID: 5, Type: "РФ", Number: "4510 123456", Primary: true},
+		{ID: 2, EmployeeID: 5, Type: "Загран", Number: "75 1234567"},
+	}, nil)
+
+	uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+	documents, err := uc.GetEmployeeDocuments(callerContext(auth.RoleViewer, 1, 0), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"****3456", "****4567"}, []string{documents[0].Number, documents[1].Number})
+}
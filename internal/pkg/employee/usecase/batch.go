@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+)
+
+// BatchEmployees validates every operation and applies the valid ones in one transaction.
+// In atomic mode a single failure cancels the whole batch, in best_effort mode failed
+// operations are reported and the rest is committed.
+func (uc *Usecase) BatchEmployees(ctx context.Context, request *models.BatchRequest) (*models.BatchResult, error) {
+	if request.Mode == "" {
+		request.Mode = models.BatchAtomic
+	}
+	if err := validateBatchRequest(request); err != nil {
+		return nil, err
+	}
+
+	var result *models.BatchResult
+	// operations are validated inside the transaction so the checked departments cannot change before the writes
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = uc.applyBatch(ctx, request)
+		return err
+	})
+	return result, err
+}
+
+func (uc *Usecase) applyBatch(ctx context.Context, request *models.BatchRequest) (*models.BatchResult, error) {
+	atomic := request.Mode == models.BatchAtomic
+
+	result := &models.BatchResult{
+		Mode:    request.Mode,
+		Results: make([]*models.BatchOperationResult, len(request.Operations)),
+	}
+	var changes []*models.EmployeeChange
+	var indexes []int
+	for i, operation := range request.Operations {
+		result.Results[i] = &models.BatchOperationResult{Index: i, Op: operation.Op, ID: operation.ID}
+		change, err := uc.newEmployeeChange(ctx, operation)
+		if err != nil {
+			result.Results[i].Status = models.BatchFailed
+			result.Results[i].Err = err
+			continue
+		}
+		changes = append(changes, change)
+		indexes = append(indexes, i)
+	}
+
+	failed := len(changes) < len(request.Operations)
+	if len(changes) > 0 && !(atomic && failed) {
+		changeResults, err := uc.repo.ApplyEmployeeChanges(ctx, changes, atomic)
+		if err != nil {
+			return nil, err
+		}
+		for i, changeResult := range changeResults {
+			operationResult := result.Results[indexes[i]]
+			if changeResult.Err != nil {
+				operationResult.Status = models.BatchFailed
+				operationResult.Err = changeResult.Err
+				failed = true
+				continue
+			}
+			operationResult.Status = models.BatchOK
+			operationResult.ID = changeResult.ID
+			operationResult.Version = changeResult.Version
+		}
+	}
+
+	result.Committed = !(atomic && failed)
+	for _, operationResult := range result.Results {
+		switch {
+		case operationResult.Status == "":
+			operationResult.Status = models.BatchSkipped
+		case operationResult.Status == models.BatchOK && !result.Committed:
+			operationResult.Status = models.BatchRolledBack
+			operationResult.Version = 0
+			if operationResult.Op == models.BatchCreate {
+				operationResult.ID = 0
+			}
+		}
+
+		switch operationResult.Status {
+		case models.BatchOK:
+			result.Succeeded++
+		case models.BatchFailed:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// newEmployeeChange validates one operation the same way the single employee endpoints do
+func (uc *Usecase) newEmployeeChange(ctx context.Context, operation *models.BatchOperation) (*models.EmployeeChange, error) {
+	if err := validateBatchOperation(operation); err != nil {
+		return nil, err
+	}
+
+	change := &models.EmployeeChange{
+		Op:      operation.Op,
+		ID:      operation.ID,
+		Version: operation.Version,
+	}
+	switch operation.Op {
+	case models.BatchCreate:
+		employee, err := uc.newEmployee(ctx, operation.Create)
+		if err != nil {
+			return nil, err
+		}
+		change.Employee = employee
+	case models.BatchUpdate:
+		operation.Update.ID = operation.ID
+		operation.Update.Version = operation.Version
+		if err := validateEmployeePatch(operation.Update); err != nil {
+			return nil, err
+		}
+		change.Update = uc.patchEmployee(ctx, operation.Update)
+	case models.BatchDelete:
+		if err := uc.authorizeEmployee(ctx, auth.ActionWriteEmployees, operation.ID); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestUsecase_BatchEmployees(t *testing.T) {
+	newRequest := func(mode string) *models.BatchRequest {
+		return &models.BatchRequest{
+			Mode: mode,
+			Operations: []*models.BatchOperation{
+				{Op: models.BatchCreate, Create: &models.CreateEmployee{
+					Name:         "katya",
+					Surname:      "ivanova",
+					Phone:        "+79161234567",
+					CompanyID:    1,
+					DepartmentID: 2,
+					Passport:     models.Passport{Type: "РФ", Number: "7878 898989"},
+				}},
+				{Op: models.BatchUpdate, ID: 5, Update: &models.UpdateEmployee{DepartmentID: lo.ToPtr(int32(2))}},
+				{Op: models.BatchDelete, ID: 6, Version: lo.ToPtr(int32(3))},
+			},
+		}
+	}
+	expectDepartment := func(m *mockEmployee.MockRepository) {
+		m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(&models.Department{ID: 2, CompanyID: 1}, nil).AnyTimes()
+	}
+
+	t.Run("atomic commit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		expectDepartment(mockRepo)
+		mockRepo.EXPECT().ApplyEmployeeChanges(gomock.Any(), gomock.Len(3), true).
+			DoAndReturn(func(_ context.Context, changes []*models.EmployeeChange, _ bool) ([]models.EmployeeChangeResult, error) {
+				assert.Equal(t, "katya", changes[0].Employee.Name)
+				assert.Equal(t, int32(6), changes[2].ID)
+				assert.Equal(t, lo.ToPtr(int32(3)), changes[2].Version)
+
+				employee := &models.Employee{ID: 5, CompanyID: 1, Department: models.Department{ID: 4}}
+				assert.NoError(t, changes[1].Update(employee))
+				assert.Equal(t, int32(2), employee.Department.ID)
+
+				return []models.EmployeeChangeResult{{ID: 10}, {ID: 5, Version: 2}, {ID: 6}}, nil
+			})
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		result, err := uc.BatchEmployees(context.Background(), newRequest(""))
+		assert.NoError(t, err)
+		assert.Equal(t, &models.BatchResult{
+			Mode:      models.BatchAtomic,
+			Committed: true,
+			Succeeded: 3,
+			Results: []*models.BatchOperationResult{
+				{Index: 0, Op: models.BatchCreate, Status: models.BatchOK, ID: 10},
+				{Index: 1, Op: models.BatchUpdate, Status: models.BatchOK, ID: 5, Version: 2},
+				{Index: 2, Op: models.BatchDelete, Status: models.BatchOK, ID: 6},
+			},
+		}, result)
+	})
+
+	t.Run("atomic rollback", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		expectDepartment(mockRepo)
+		mockRepo.EXPECT().ApplyEmployeeChanges(gomock.Any(), gomock.Len(3), true).
+			Return([]models.EmployeeChangeResult{{ID: 10}, {ID: 5, Err: errs.ErrNotFound}}, nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		result, err := uc.BatchEmployees(context.Background(), newRequest(models.BatchAtomic))
+		assert.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.Equal(t, 0, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, &models.BatchOperationResult{Index: 0, Op: models.BatchCreate, Status: models.BatchRolledBack}, result.Results[0])
+		assert.Equal(t, models.BatchFailed, result.Results[1].Status)
+		assert.ErrorIs(t, result.Results[1].Err, errs.ErrNotFound)
+		assert.Equal(t, models.BatchSkipped, result.Results[2].Status)
+	})
+
+	t.Run("atomic invalid operation is not applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		expectDepartment(mockRepo)
+
+		request := newRequest(models.BatchAtomic)
+		request.Operations[2].ID = 0
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		result, err := uc.BatchEmployees(context.Background(), request)
+		assert.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.Equal(t, models.BatchSkipped, result.Results[0].Status)
+		assert.Equal(t, models.BatchSkipped, result.Results[1].Status)
+		assert.Equal(t, models.BatchFailed, result.Results[2].Status)
+		assert.Equal(t, &errs.ValidationError{Fields: []errs.FieldError{{Field: "id", Message: "must be a positive id"}}}, result.Results[2].Err)
+	})
+
+	t.Run("best effort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		expectDepartment(mockRepo)
+		mockRepo.EXPECT().ApplyEmployeeChanges(gomock.Any(), gomock.Len(2), false).
+			Return([]models.EmployeeChangeResult{{ID: 10}, {ID: 6, Err: errs.ErrPreconditionFailed}}, nil)
+
+		request := newRequest(models.BatchBestEffort)
+		request.Operations[1].Update.DepartmentID = lo.ToPtr(int32(0))
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		result, err := uc.BatchEmployees(context.Background(), request)
+		assert.NoError(t, err)
+		assert.True(t, result.Committed)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, models.BatchOK, result.Results[0].Status)
+		assert.Equal(t, int32(10), result.Results[0].ID)
+		assert.ErrorIs(t, result.Results[1].Err, errs.ErrValidation)
+		assert.ErrorIs(t, result.Results[2].Err, errs.ErrPreconditionFailed)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := &Usecase{repo: mockEmployee.NewMockRepository(ctrl), tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.BatchEmployees(context.Background(), &models.BatchRequest{Mode: "all"})
+		assert.Equal(t, &errs.ValidationError{Fields: []errs.FieldError{
+			{Field: "mode", Message: "must be atomic or best_effort"},
+			{Field: "operations", Message: "is required"},
+		}}, err)
+	})
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"employees/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// cursorPayload is serialized into the opaque next_cursor value.
+// Sort is stored to reject cursors reused with another sort order.
+type cursorPayload struct {
+	Sort  string `json:"s"`
+	Value string `json:"v,omitempty"`
+	ID    int32  `json:"id"`
+}
+
+func encodeCursor(sort string, employee *models.Employee) string {
+	payload := cursorPayload{Sort: sort, ID: employee.ID}
+	switch sort {
+	case models.SortByName:
+		payload.Value = employee.Name
+	case models.SortBySurname:
+		payload.Value = employee.Surname
+	case models.SortByCreatedAt:
+		payload.Value = employee.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(sort, cursor string) (*models.EmployeeCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err = json.Unmarshal(data, &payload); err != nil || payload.Sort != sort || payload.ID <= 0 {
+		return nil, errInvalidCursor
+	}
+
+	decoded := &models.EmployeeCursor{ID: payload.ID}
+	switch sort {
+	case models.SortByName, models.SortBySurname:
+		decoded.Text = payload.Value
+	case models.SortByCreatedAt:
+		decoded.CreatedAt, err = time.Parse(time.RFC3339Nano, payload.Value)
+		if err != nil {
+			return nil, errInvalidCursor
+		}
+	}
+	return decoded, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"employees/internal/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC)
+	employee := &models.Employee{ID: 42, Name: "katya", Surname: "ivanova", CreatedAt: createdAt}
+
+	testTable := []struct {
+		sort     string
+		expected *models.EmployeeCursor
+	}{
+		{sort: models.SortByID, expected: &models.EmployeeCursor{ID: 42}},
+		{sort: models.SortByName, expected: &models.EmployeeCursor{ID: 42, Text: "katya"}},
+		{sort: models.SortBySurname, expected: &models.EmployeeCursor{ID: 42, Text: "ivanova"}},
+		{sort: models.SortByCreatedAt, expected: &models.EmployeeCursor{ID: 42, CreatedAt: createdAt}},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.sort, func(t *testing.T) {
+			cursor, err := decodeCursor(tt.sort, encodeCursor(tt.sort, employee))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cursor)
+		})
+	}
+
+	_, err := decodeCursor(models.SortByID, "not a cursor")
+	assert.ErrorIs(t, err, errInvalidCursor)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	"employees/internal/pkg/errs"
+)
+
+func (uc *Usecase) GetEmployeeDocuments(ctx context.Context, employeeID int32) ([]*models.IdentityDocument, error) {
+	employee, err := uc.GetEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := uc.repo.ListIdentityDocuments(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	maskDocuments(ctx, employee, documents...)
+	return documents, nil
+}
+
+func (uc *Usecase) GetEmployeeDocument(ctx context.Context, employeeID, id int32) (*models.IdentityDocument, error) {
+	employee, err := uc.GetEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	document, err := uc.repo.GetIdentityDocument(ctx, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	maskDocuments(ctx, employee, document)
+	return document, nil
+}
+
+func (uc *Usecase) CreateEmployeeDocument(ctx context.Context, document *models.CreateIdentityDocument) (int32, error) {
+	documentData := &models.IdentityDocument{
+		EmployeeID: document.EmployeeID,
+		Type:       document.Type,
+		Number:     document.Number,
+		Issuer:     document.Issuer,
+		IssueDate:  document.IssueDate,
+		ExpiryDate: document.ExpiryDate,
+		Country:    document.Country,
+		Primary:    document.Primary,
+	}
+	if err := validateIdentityDocument(documentData); err != nil {
+		return 0, err
+	}
+	if err := uc.authorizeEmployee(ctx, auth.ActionWriteEmployees, document.EmployeeID); err != nil {
+		return 0, err
+	}
+
+	id, err := uc.repo.CreateIdentityDocument(ctx, documentData)
+	return id, err
+}
+
+// EditEmployeeDocument applies the present fields to the locked document and validates the result.
+// The primary flag can only be moved to another document, not taken away.
+func (uc *Usecase) EditEmployeeDocument(ctx context.Context, document *models.UpdateIdentityDocument) error {
+	if err := uc.authorizeEmployee(ctx, auth.ActionWriteEmployees, document.EmployeeID); err != nil {
+		return err
+	}
+	err := uc.repo.EditIdentityDocument(ctx, document.EmployeeID, document.ID, func(documentData *models.IdentityDocument) error {
+		if document.Primary != nil && !*document.Primary && documentData.Primary {
+			return &errs.ValidationError{Fields: []errs.FieldError{{Field: "primary", Message: "make another document primary instead"}}}
+		}
+		applyDocumentPatch(documentData, document)
+		return validateIdentityDocument(documentData)
+	})
+	return err
+}
+
+func (uc *Usecase) DeleteEmployeeDocument(ctx context.Context, employeeID, id int32) error {
+	if err := uc.authorizeEmployee(ctx, auth.ActionWriteEmployees, employeeID); err != nil {
+		return err
+	}
+	err := uc.repo.DeleteIdentityDocument(ctx, employeeID, id)
+	return err
+}
+
+func applyDocumentPatch(documentData *models.IdentityDocument, document *models.UpdateIdentityDocument) {
+	if document.Type != nil {
+		documentData.Type = *document.Type
+	}
+	if document.Number != nil {
+		documentData.Number = *document.Number
+	}
+	if document.Issuer != nil {
+		documentData.Issuer = *document.Issuer
+	}
+	if document.IssueDate != nil {
+		documentData.IssueDate = *document.IssueDate
+	}
+	if document.ExpiryDate != nil {
+		documentData.ExpiryDate = *document.ExpiryDate
+	}
+	if document.Country != nil {
+		documentData.Country = *document.Country
+	}
+	if document.Primary != nil {
+		documentData.Primary = *document.Primary
+	}
+}
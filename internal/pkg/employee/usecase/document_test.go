@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestUsecase_CreateEmployeeDocument(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockRepository)
+
+	testTable := []struct {
+		name          string
+		document      *models.CreateIdentityDocument
+		mockBehavior  mockBehavior
+		expectedID    int32
+		expectedError error
+	}{
+		{
+			name: "ok",
+			document: &models.CreateIdentityDocument{
+				EmployeeID: 1, Type: "загран", Number: "75 1234567", IssueDate: "2020-01-15", ExpiryDate: "2030-01-15", Country: "RU",
+			},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().CreateIdentityDocument(gomock.Any(), &models.IdentityDocument{
+					EmployeeID: 1, Type: "загран", Number: "75 1234567", IssueDate: "2020-01-15", ExpiryDate: "2030-01-15", Country: "RU",
+				}).Return(int32(7), nil)
+			},
+			expectedID: 7,
+		},
+		{
+			name:          "expiry before issue",
+			document:      &models.CreateIdentityDocument{EmployeeID: 1, Type: "РФ", Number: "4510 123456", IssueDate: "2020-01-15", ExpiryDate: "2019-01-15"},
+			mockBehavior:  func(m *mockEmployee.MockRepository) {},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name:     "duplicate type and number",
+			document: &models.CreateIdentityDocument{EmployeeID: 1, Type: "РФ", Number: "4510 123456"},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().CreateIdentityDocument(gomock.Any(), gomock.Any()).Return(int32(0), errs.ErrConflict)
+			},
+			expectedError: errs.ErrConflict,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			testCase.mockBehavior(mockRepo)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			id, err := uc.CreateEmployeeDocument(context.Background(), testCase.document)
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedID, id)
+		})
+	}
+}
+
+func TestUsecase_EditEmployeeDocument(t *testing.T) {
+	testTable := []struct {
+		name             string
+		current          models.IdentityDocument
+		patch            *models.UpdateIdentityDocument
+		expectedDocument models.IdentityDocument
+		expectedError    error
+	}{
+		{
+			name:    "change number and remove expiry date",
+			current: models.IdentityDocument{ID: 3, EmployeeID: 1, Type: "загран", Number: "75 1234567", ExpiryDate: "2030-01-15"},
+			patch:   &models.UpdateIdentityDocument{ID: 3, EmployeeID: 1, Number: lo.ToPtr("76 7654321"), ExpiryDate: lo.ToPtr("")},
+			expectedDocument: models.IdentityDocument{
+				ID: 3, EmployeeID: 1, Type: "загран", Number: "76 7654321",
+			},
+		},
+		{
+			name:    "make primary",
+			current: models.IdentityDocument{ID: 3, EmployeeID: 1, Type: "загран", Number: "75 1234567"},
+			patch:   &models.UpdateIdentityDocument{ID: 3, EmployeeID: 1, Primary: lo.ToPtr(true)},
+			expectedDocument: models.IdentityDocument{
+				ID: 3, EmployeeID: 1, Type: "загран", Number: "75 1234567", Primary: true,
+			},
+		},
+		{
+			name:          "type change breaks the number format",
+			current:       models.IdentityDocument{ID: 3, EmployeeID: 1, Type: "загран", Number: "75 1234567"},
+			patch:         &models.UpdateIdentityDocument{ID: 3, EmployeeID: 1, Type: lo.ToPtr("РБ")},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name:          "unset primary",
+			current:       models.IdentityDocument{ID: 3, EmployeeID: 1, Type: "РФ", Number: "4510 123456", Primary: true},
+			patch:         &models.UpdateIdentityDocument{ID: 3, EmployeeID: 1, Primary: lo.ToPtr(false)},
+			expectedError: errs.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			document := testCase.current
+			mockRepo.EXPECT().EditIdentityDocument(gomock.Any(), int32(1), int32(3), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ int32, update func(document *models.IdentityDocument) error) error {
+					return update(&document)
+				})
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			err := uc.EditEmployeeDocument(context.Background(), testCase.patch)
+			assert.ErrorIs(t, err, testCase.expectedError)
+			if testCase.expectedError == nil {
+				assert.Equal(t, testCase.expectedDocument, document)
+			}
+		})
+	}
+}
+
+func TestUsecase_GetEmployeeDocuments(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		documents := []*models.IdentityDocument{
+			{ID: 1, EmployeeID: 1, Type: "РФ", Number: "4510 123456", Primary: true},
+			{ID: 2, EmployeeID: 1, Type: "загран", Number: "75 1234567"},
+		}
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(1)).Return(&models.Employee{ID: 1}, nil)
+		mockRepo.EXPECT().ListIdentityDocuments(gomock.Any(), int32(1)).Return(documents, nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		got, err := uc.GetEmployeeDocuments(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, documents, got)
+	})
+
+	t.Run("employee not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetEmployeeDocuments(context.Background(), 1)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	"employees/internal/pkg/errs"
+	"errors"
+	"fmt"
+)
+
+func (uc *Usecase) GetDepartmentSubtree(ctx context.Context, id int32) (*models.DepartmentTree, error) {
+	if err := uc.authorizeDepartment(ctx, auth.ActionReadCompany, id); err != nil {
+		return nil, err
+	}
+	departments, err := uc.repo.GetDepartmentSubtree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return departmentForest(departments)[0], nil
+}
+
+// GetReportingChain returns the managers of the employee from the direct one up to the top,
+// managers the caller may not read are left out
+func (uc *Usecase) GetReportingChain(ctx context.Context, id int32) ([]*models.Employee, error) {
+	if err := uc.authorizeEmployee(ctx, auth.ActionReadEmployees, id); err != nil {
+		return nil, err
+	}
+	chain, err := uc.repo.GetReportingChain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	managers := readableEmployees(ctx, chain[1:])
+	maskEmployees(ctx, managers...)
+	return managers, nil
+}
+
+// GetDirectReports returns the employees the employee manages, those the caller may not read are left out
+func (uc *Usecase) GetDirectReports(ctx context.Context, id int32) ([]*models.Employee, error) {
+	if err := uc.authorizeEmployee(ctx, auth.ActionReadEmployees, id); err != nil {
+		return nil, err
+	}
+	if _, err := uc.repo.GetEmployeeByID(ctx, id); err != nil {
+		return nil, err
+	}
+	reports, err := uc.repo.ListDirectReports(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	reports = readableEmployees(ctx, reports)
+	maskEmployees(ctx, reports...)
+	return reports, nil
+}
+
+// checkManager makes sure the manager is an active employee of the company who does not report
+// to the employee, employeeID is 0 for an employee that is not created yet
+func (uc *Usecase) checkManager(ctx context.Context, employeeID, managerID, companyID int32) error {
+	chain, err := uc.repo.GetReportingChain(ctx, managerID)
+	if errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("%w: manager %d", errs.ErrInvalidReference, managerID)
+	}
+	if err != nil {
+		return err
+	}
+	if chain[0].CompanyID != companyID {
+		return fmt.Errorf("%w: manager %d belongs to company %d", errs.ErrInvalidReference, managerID, chain[0].CompanyID)
+	}
+	for _, manager := range chain {
+		if manager.ID == employeeID {
+			return fmt.Errorf("%w: employee %d manages %d", errs.ErrHierarchyCycle, employeeID, managerID)
+		}
+	}
+	return nil
+}
+
+// checkParentDepartment makes sure the parent belongs to the company and is not inside the subtree
+// of the department, departmentID is 0 for a department that is not created yet
+func (uc *Usecase) checkParentDepartment(ctx context.Context, departmentID, parentID, companyID int32) error {
+	if err := uc.checkDepartmentCompany(ctx, parentID, companyID); err != nil {
+		return err
+	}
+	if departmentID == 0 {
+		return nil
+	}
+
+	subtree, err := uc.repo.GetDepartmentSubtree(ctx, departmentID)
+	if err != nil {
+		return err
+	}
+	for _, department := range subtree {
+		if department.ID == parentID {
+			return fmt.Errorf("%w: department %d contains %d", errs.ErrHierarchyCycle, departmentID, parentID)
+		}
+	}
+	return nil
+}
+
+// departmentForest links departments to their parents, departments whose parent is not in the list become roots.
+// Roots and children keep the order of the list.
+func departmentForest(departments []*models.Department) []*models.DepartmentTree {
+	nodes := make(map[int32]*models.DepartmentTree, len(departments))
+	for _, department := range departments {
+		nodes[department.ID] = &models.DepartmentTree{
+			DepartmentInfo: *departmentInfo(department),
+			Children:       []*models.DepartmentTree{},
+		}
+	}
+
+	roots := make([]*models.DepartmentTree, 0)
+	for _, department := range departments {
+		node := nodes[department.ID]
+		if department.ParentID != nil {
+			if parent, ok := nodes[*department.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestUsecase_GetDepartmentSubtree(t *testing.T) {
+	t.Run("nested departments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(1)).Return([]*models.Department{
+			{ID: 1, Name: "dev", CompanyID: 1, ParentID: lo.ToPtr(int32(9))},
+			{ID: 2, Name: "backend", CompanyID: 1, ParentID: lo.ToPtr(int32(1))},
+			{ID: 4, Name: "db", CompanyID: 1, ParentID: lo.ToPtr(int32(2))},
+			{ID: 3, Name: "frontend", CompanyID: 1, ParentID: lo.ToPtr(int32(1))},
+		}, nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		tree, err := uc.GetDepartmentSubtree(context.Background(), 1)
+		assert.NoError(t, err)
+
+		node := func(id int32, name string, parentID int32, children ...*models.DepartmentTree) *models.DepartmentTree {
+			return &models.DepartmentTree{
+				DepartmentInfo: models.DepartmentInfo{ID: id, Name: name, CompanyID: 1, ParentID: lo.ToPtr(parentID)},
+				Children:       append([]*models.DepartmentTree{}, children...),
+			}
+		}
+		assert.Equal(t, node(1, "dev", 9,
+			node(2, "backend", 1, node(4, "db", 2)),
+			node(3, "frontend", 1),
+		), tree)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetDepartmentSubtree(context.Background(), 1)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestUsecase_EditDepartment_Parent(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockRepository)
+
+	testTable := []struct {
+		name          string
+		parentID      int32
+		mockBehavior  mockBehavior
+		expectedError error
+	}{
+		{
+			name:     "parent outside the subtree",
+			parentID: 5,
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(1)).Return(&models.Department{ID: 1, CompanyID: 1}, nil)
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(5)).Return(&models.Department{ID: 5, CompanyID: 1}, nil)
+				m.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(1)).Return([]*models.Department{{ID: 1}, {ID: 2}}, nil)
+				m.EXPECT().EditDepartment(gomock.Any(), &models.Department{ID: 1, ParentID: lo.ToPtr(int32(5))}).Return(nil)
+			},
+		},
+		{
+			name:     "parent inside the subtree",
+			parentID: 2,
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(1)).Return(&models.Department{ID: 1, CompanyID: 1}, nil)
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(&models.Department{ID: 2, CompanyID: 1}, nil)
+				m.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(1)).Return([]*models.Department{{ID: 1}, {ID: 2}}, nil)
+			},
+			expectedError: errs.ErrHierarchyCycle,
+		},
+		{
+			name:     "department as its own parent",
+			parentID: 1,
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(1)).Return(&models.Department{ID: 1, CompanyID: 1}, nil).Times(2)
+				m.EXPECT().GetDepartmentSubtree(gomock.Any(), int32(1)).Return([]*models.Department{{ID: 1}}, nil)
+			},
+			expectedError: errs.ErrHierarchyCycle,
+		},
+		{
+			name:     "parent of another company",
+			parentID: 5,
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(1)).Return(&models.Department{ID: 1, CompanyID: 1}, nil)
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(5)).Return(&models.Department{ID: 5, CompanyID: 2}, nil)
+			},
+			expectedError: errs.ErrDepartmentCompanyMismatch,
+		},
+		{
+			name:     "top level",
+			parentID: 0,
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().EditDepartment(gomock.Any(), &models.Department{ID: 1, ParentID: lo.ToPtr(int32(0))}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			tt.mockBehavior(mockRepo)
+
+			uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+			err := uc.EditDepartment(context.Background(), &models.CreateDepartment{ID: 1, ParentID: lo.ToPtr(tt.parentID)})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUsecase_GetReportingChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockEmployee.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetReportingChain(gomock.Any(), int32(1)).Return([]*models.Employee{
+		{ID: 1, ManagerID: lo.ToPtr(int32(2))},
+		{ID: 2, ManagerID: lo.ToPtr(int32(3))},
+		{ID: 3},
+	}, nil)
+
+	uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+	chain, err := uc.GetReportingChain(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Employee{{ID: 2, ManagerID: lo.ToPtr(int32(3))}, {ID: 3}}, chain)
+}
+
+func TestUsecase_GetReportingChainAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockEmployee.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetEmployeeScope(gomock.Any(), int32(1)).Return(&models.EmployeeScope{CompanyID: 1, DepartmentID: 10}, nil)
+	mockRepo.EXPECT().GetReportingChain(gomock.Any(), int32(1)).Return([]*models.Employee{
+		{ID: 1, CompanyID: 1, Department: models.Department{ID: 10}, ManagerID: lo.ToPtr(int32(2))},
+		{ID: 2, CompanyID: 1, Department: models.Department{ID: 10}, ManagerID: lo.ToPtr(int32(3))},
+		{ID: 3, CompanyID: 1, Department: models.Department{ID: 1}},
+	}, nil)
+
+	uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+	chain, err := uc.GetReportingChain(callerContext(auth.RoleDepartmentHead, 1, 10), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{2}, lo.Map(chain, func(e *models.Employee, _ int) int32 { return e.ID }))
+}
+
+func TestUsecase_GetDirectReports(t *testing.T) {
+	t.Run("reports", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		reports := []*models.Employee{{ID: 2, ManagerID: lo.ToPtr(int32(1))}}
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(1)).Return(&models.Employee{ID: 1}, nil)
+		mockRepo.EXPECT().ListDirectReports(gomock.Any(), int32(1)).Return(reports, nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		result, err := uc.GetDirectReports(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, reports, result)
+	})
+
+	t.Run("employee not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetDirectReports(context.Background(), 1)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("reports outside the scope of the caller are left out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeScope(gomock.Any(), int32(1)).Return(&models.EmployeeScope{CompanyID: 1, DepartmentID: 10}, nil)
+		mockRepo.EXPECT().GetEmployeeByID(gomock.Any(), int32(1)).Return(&models.Employee{ID: 1, CompanyID: 1}, nil)
+		mockRepo.EXPECT().ListDirectReports(gomock.Any(), int32(1)).Return([]*models.Employee{
+			{ID: 2, CompanyID: 1, Department: models.Department{ID: 10}, ManagerID: lo.ToPtr(int32(1))},
+			{ID: 3, CompanyID: 1, Department: models.Department{ID: 11}, ManagerID: lo.ToPtr(int32(1))},
+		}, nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		reports, err := uc.GetDirectReports(callerContext(auth.RoleDepartmentHead, 1, 10), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{2}, lo.Map(reports, func(e *models.Employee, _ int) int32 { return e.ID }))
+	})
+
+	t.Run("missing employee looks like one outside the scope", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetEmployeeScope(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetDirectReports(callerContext(auth.RoleViewer, 1, 0), 1)
+		assert.ErrorIs(t, err, errs.ErrForbidden)
+	})
+}
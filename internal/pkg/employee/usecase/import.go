@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+	"employees/internal/pkg/errs"
+	"fmt"
+)
+
+// ImportEmployees validates every row and creates the valid ones in one go.
+// Invalid rows are reported and skipped; with dryRun nothing is written.
+func (uc *Usecase) ImportEmployees(ctx context.Context, companyID int32, rows []*models.ImportEmployee, dryRun bool) (*models.ImportReport, error) {
+	if err := auth.Authorize(ctx, auth.ActionWriteEmployees, auth.Resource{CompanyID: companyID}); err != nil {
+		return nil, err
+	}
+	var report *models.ImportReport
+	// the uniqueness checks and the insert run in one transaction
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		report, err = uc.importEmployees(ctx, companyID, rows, dryRun)
+		return err
+	})
+	return report, err
+}
+
+func (uc *Usecase) importEmployees(ctx context.Context, companyID int32, rows []*models.ImportEmployee, dryRun bool) (*models.ImportReport, error) {
+	if _, err := uc.repo.GetCompanyByID(ctx, companyID); err != nil {
+		return nil, err
+	}
+	departments, err := uc.repo.GetCompanyDepartments(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	departmentIDs := make(map[string]int32, len(departments))
+	for _, department := range departments {
+		departmentIDs[department.Name] = department.ID
+	}
+
+	report := &models.ImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]models.ImportRowResult, len(rows)),
+	}
+	phoneLines := make(map[string]int, len(rows))
+	passportLines := make(map[string]int, len(rows))
+	var valid []int
+	for i, row := range rows {
+		result := &report.Rows[i]
+		result.Line = row.Line
+		if row.ParseError != "" {
+			result.Errors = []errs.FieldError{{Field: "line", Message: row.ParseError}}
+			continue
+		}
+
+		result.Errors = validateImportEmployee(row, departmentIDs)
+		if line, ok := phoneLines[row.Phone]; ok {
+			result.Errors = append(result.Errors, errs.FieldError{Field: "phone", Message: fmt.Sprintf("duplicates line %d", line)})
+		}
+		if line, ok := passportLines[row.Passport.Number]; ok {
+			result.Errors = append(result.Errors, errs.FieldError{Field: "passport.number", Message: fmt.Sprintf("duplicates line %d", line)})
+		}
+		if len(result.Errors) > 0 {
+			continue
+		}
+		phoneLines[row.Phone] = row.Line
+		passportLines[row.Passport.Number] = row.Line
+		valid = append(valid, i)
+	}
+
+	valid, err = uc.skipExistingEmployees(ctx, report, rows, valid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun && len(valid) > 0 {
+		employees := make([]*models.Employee, len(valid))
+		for i, idx := range valid {
+			row := rows[idx]
+			employees[i] = &models.Employee{
+				Name:       row.Name,
+				Surname:    row.Surname,
+				Phone:      row.Phone,
+				CompanyID:  companyID,
+				Passport:   row.Passport,
+				Department: models.Department{ID: departmentIDs[row.Department]},
+			}
+		}
+
+		ids, err := uc.repo.ImportEmployees(ctx, employees)
+		if err != nil {
+			return nil, err
+		}
+		for i, idx := range valid {
+			report.Rows[idx].ID = ids[i]
+		}
+	}
+
+	for i := range report.Rows {
+		result := &report.Rows[i]
+		switch {
+		case len(result.Errors) > 0:
+			result.Status = models.ImportFailed
+			report.Failed++
+		case dryRun:
+			result.Status = models.ImportValid
+		default:
+			result.Status = models.ImportCreated
+			report.Created++
+		}
+	}
+	return report, nil
+}
+
+// skipExistingEmployees fails the rows whose phone already belongs to an active employee
+// or whose passport is already a document of any employee
+func (uc *Usecase) skipExistingEmployees(ctx context.Context, report *models.ImportReport, rows []*models.ImportEmployee, valid []int) ([]int, error) {
+	if len(valid) == 0 {
+		return valid, nil
+	}
+
+	phones := make([]string, len(valid))
+	passportNumbers := make([]string, len(valid))
+	for i, idx := range valid {
+		phones[i] = rows[idx].Phone
+		passportNumbers[i] = rows[idx].Passport.Number
+	}
+	existing, err := uc.repo.FindEmployeesByIdentifiers(ctx, phones, passportNumbers)
+	if err != nil {
+		return nil, err
+	}
+	takenPhones := make(map[string]int32, len(existing))
+	takenPassports := make(map[string]int32, len(existing))
+	for _, employee := range existing {
+		takenPhones[employee.Phone] = employee.ID
+		takenPassports[employee.PassportNumber] = employee.ID
+	}
+
+	remaining := valid[:0]
+	for _, idx := range valid {
+		result := &report.Rows[idx]
+		if id, ok := takenPhones[rows[idx].Phone]; ok {
+			result.Errors = append(result.Errors, errs.FieldError{Field: "phone", Message: fmt.Sprintf("already belongs to employee %d", id)})
+		}
+		if id, ok := takenPassports[rows[idx].Passport.Number]; ok {
+			result.Errors = append(result.Errors, errs.FieldError{Field: "passport.number", Message: fmt.Sprintf("already belongs to employee %d", id)})
+		}
+		if len(result.Errors) == 0 {
+			remaining = append(remaining, idx)
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestUsecase_ImportEmployees(t *testing.T) {
+	row := func(line int, phone, passportNumber string) *models.ImportEmployee {
+		return &models.ImportEmployee{
+			Line:       line,
+			Name:       "katya",
+			Surname:    "ivanova",
+			Phone:      phone,
+			Department: "dev",
+			Passport:   models.Passport{Type: "РФ", Number: passportNumber},
+		}
+	}
+	rows := []*models.ImportEmployee{
+		row(2, "+79161234567", "7878 898989"),
+		{Line: 3, ParseError: "expected 6 fields, got 3"},
+		row(4, "+79161234567", "1111 222222"),
+		row(5, "+79160000000", "2222 333333"),
+		{Line: 6, Name: "ivan", Surname: "", Phone: "+79161111111", Department: "sales", Passport: models.Passport{Type: "РФ", Number: "3333 444444"}},
+		row(7, "+79162222222", "4444 555555"),
+	}
+
+	expectRepo := func(m *mockEmployee.MockRepository) {
+		m.EXPECT().GetCompanyByID(gomock.Any(), int32(1)).Return(&models.Company{ID: 1, Name: "company"}, nil)
+		m.EXPECT().GetCompanyDepartments(gomock.Any(), int32(1)).Return([]*models.Department{{ID: 2, Name: "dev", CompanyID: 1}}, nil)
+		m.EXPECT().FindEmployeesByIdentifiers(gomock.Any(),
+			[]string{"+79161234567", "+79160000000", "+79162222222"},
+			[]string{"7878 898989", "2222 333333", "4444 555555"},
+		).Return([]*models.EmployeeIdentifiers{{ID: 9, Phone: "+79160000000", PassportNumber: "9999 999999"}}, nil)
+	}
+	expectedRows := func(status string, ids ...int32) []models.ImportRowResult {
+		return []models.ImportRowResult{
+			{Line: 2, Status: status, ID: ids[0]},
+			{Line: 3, Status: models.ImportFailed, Errors: []errs.FieldError{{Field: "line", Message: "expected 6 fields, got 3"}}},
+			{Line: 4, Status: models.ImportFailed, Errors: []errs.FieldError{{Field: "phone", Message: "duplicates line 2"}}},
+			{Line: 5, Status: models.ImportFailed, Errors: []errs.FieldError{{Field: "phone", Message: "already belongs to employee 9"}}},
+			{Line: 6, Status: models.ImportFailed, Errors: []errs.FieldError{
+				{Field: "surname", Message: "is required"},
+				{Field: "department", Message: "not found in company"},
+			}},
+			{Line: 7, Status: status, ID: ids[1]},
+		}
+	}
+
+	t.Run("create valid rows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		expectRepo(mockRepo)
+		mockRepo.EXPECT().ImportEmployees(gomock.Any(), gomock.Len(2)).
+			DoAndReturn(func(ctx context.Context, employees []*models.Employee) ([]int32, error) {
+				assert.Equal(t, int32(1), employees[0].CompanyID)
+				assert.Equal(t, int32(2), employees[0].Department.ID)
+				assert.Equal(t, "+79162222222", employees[1].Phone)
+				return []int32{10, 11}, nil
+			})
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		report, err := uc.ImportEmployees(context.Background(), 1, rows, false)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ImportReport{
+			Total:   6,
+			Created: 2,
+			Failed:  4,
+			Rows:    expectedRows(models.ImportCreated, 10, 11),
+		}, report)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		expectRepo(mockRepo)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		report, err := uc.ImportEmployees(context.Background(), 1, rows, true)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ImportReport{
+			DryRun: true,
+			Total:  6,
+			Failed: 4,
+			Rows:   expectedRows(models.ImportValid, 0, 0),
+		}, report)
+	})
+
+	t.Run("unknown company", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetCompanyByID(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.ImportEmployees(context.Background(), 1, rows, false)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	"employees/internal/pkg/auth"
+)
+
+// GetOrgChart builds the company department tree with the active employees of every department
+func (uc *Usecase) GetOrgChart(ctx context.Context, companyID int32) (*models.OrgChart, error) {
+	if err := auth.Authorize(ctx, auth.ActionReadEmployees, auth.Resource{CompanyID: companyID}); err != nil {
+		return nil, err
+	}
+	company, err := uc.repo.GetCompanyByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	departments, err := uc.repo.GetCompanyDepartments(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	employees := make(map[int32][]*models.OrgEmployee)
+	err = uc.repo.ExportEmployees(ctx, companyID, func(employee *models.Employee) error {
+		employees[employee.Department.ID] = append(employees[employee.Department.ID], &models.OrgEmployee{
+			ID:      employee.ID,
+			Name:    employee.Name,
+			Surname: employee.Surname,
+			Phone:   employee.Phone,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OrgChart{
+		ID:          company.ID,
+		Name:        company.Name,
+		Departments: orgDepartments(departmentForest(departments), employees),
+	}, nil
+}
+
+func orgDepartments(trees []*models.DepartmentTree, employees map[int32][]*models.OrgEmployee) []*models.OrgDepartment {
+	departments := make([]*models.OrgDepartment, len(trees))
+	for i, tree := range trees {
+		departments[i] = &models.OrgDepartment{
+			ID:          tree.ID,
+			Name:        tree.Name,
+			Phone:       tree.Phone,
+			Employees:   employees[tree.ID],
+			Departments: orgDepartments(tree.Children, employees),
+		}
+		if departments[i].Employees == nil {
+			departments[i].Employees = []*models.OrgEmployee{}
+		}
+	}
+	return departments
+}
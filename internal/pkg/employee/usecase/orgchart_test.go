@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestUsecase_GetOrgChart(t *testing.T) {
+	t.Run("departments with employees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetCompanyByID(gomock.Any(), int32(1)).Return(&models.Company{ID: 1, Name: "company"}, nil)
+		mockRepo.EXPECT().GetCompanyDepartments(gomock.Any(), int32(1)).Return([]*models.Department{
+			{ID: 2, Name: "dev", Phone: "1234", CompanyID: 1},
+			{ID: 3, Name: "backend", Phone: "5678", CompanyID: 1, ParentID: lo.ToPtr(int32(2))},
+			{ID: 4, Name: "sales", Phone: "9012", CompanyID: 1},
+		}, nil)
+		mockRepo.EXPECT().ExportEmployees(gomock.Any(), int32(1), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, companyID int32, fn func(*models.Employee) error) error {
+				for _, employee := range []*models.Employee{
+					{ID: 5, Name: "katya", Surname: "ivanova", Phone: "+79161234567", Department: models.Department{ID: 3}},
+					{ID: 6, Name: "ivan", Surname: "petrov", Phone: "+79160000000", Department: models.Department{ID: 4}},
+					{ID: 7, Name: "anna", Surname: "smirnova", Phone: "+79161111111", Department: models.Department{ID: 3}},
+				} {
+					if err := fn(employee); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		chart, err := uc.GetOrgChart(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OrgChart{
+			ID:   1,
+			Name: "company",
+			Departments: []*models.OrgDepartment{
+				{
+					ID:        2,
+					Name:      "dev",
+					Phone:     "1234",
+					Employees: []*models.OrgEmployee{},
+					Departments: []*models.OrgDepartment{
+						{
+							ID:    3,
+							Name:  "backend",
+							Phone: "5678",
+							Employees: []*models.OrgEmployee{
+								{ID: 5, Name: "katya", Surname: "ivanova", Phone: "+79161234567"},
+								{ID: 7, Name: "anna", Surname: "smirnova", Phone: "+79161111111"},
+							},
+							Departments: []*models.OrgDepartment{},
+						},
+					},
+				},
+				{
+					ID:          4,
+					Name:        "sales",
+					Phone:       "9012",
+					Employees:   []*models.OrgEmployee{{ID: 6, Name: "ivan", Surname: "petrov", Phone: "+79160000000"}},
+					Departments: []*models.OrgDepartment{},
+				},
+			},
+		}, chart)
+	})
+
+	t.Run("unknown company", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetCompanyByID(gomock.Any(), int32(1)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetOrgChart(context.Background(), 1)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
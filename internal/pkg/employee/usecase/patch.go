@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"employees/internal/models"
+	"slices"
+)
+
+// removableEmployeeField is the only employee field a merge patch may set to null
+const removableEmployeeField = "manager_id"
+
+// applyEmployeePatch copies every field present in the patch onto the employee
+func applyEmployeePatch(employee *models.Employee, patch *models.UpdateEmployee) {
+	if patch.Name != nil {
+		employee.Name = *patch.Name
+	}
+	if patch.Surname != nil {
+		employee.Surname = *patch.Surname
+	}
+	if patch.Phone != nil {
+		employee.Phone = *patch.Phone
+	}
+	if patch.CompanyID != nil {
+		employee.CompanyID = *patch.CompanyID
+	}
+	if patch.DepartmentID != nil {
+		employee.Department.ID = *patch.DepartmentID
+	}
+	if patch.ManagerID != nil {
+		employee.ManagerID = patch.ManagerID
+	}
+	if slices.Contains(patch.NullFields, removableEmployeeField) {
+		employee.ManagerID = nil
+	}
+	if patch.Passport != nil {
+		if patch.Passport.Type != nil {
+			employee.Passport.Type = *patch.Passport.Type
+		}
+		if patch.Passport.Number != nil {
+			employee.Passport.Number = *patch.Passport.Number
+		}
+	}
+}
@@ -3,32 +3,73 @@ package usecase
 import (
 	"context"
 	"employees/internal/models"
+	"employees/internal/pkg/auth"
 	"employees/internal/pkg/employee"
+	"employees/internal/pkg/errs"
+	"errors"
+	"fmt"
 	"go.uber.org/fx"
 	"log/slog"
+	"strings"
+	"time"
 )
 
 type Params struct {
 	fx.In
 
 	Repo   employee.Repository
+	Tx     employee.TxManager
 	Logger *slog.Logger
 }
 
 type Usecase struct {
 	repo employee.Repository
+	tx   employee.TxManager
 	log  *slog.Logger
 }
 
 func New(p Params) *Usecase {
 	return &Usecase{
 		repo: p.Repo,
+		tx:   p.Tx,
 		log:  p.Logger,
 	}
 }
 
 func (uc *Usecase) CreateEmployee(ctx context.Context, employee *models.CreateEmployee) (int32, error) {
-	employeeData := &models.Employee{
+	var id int32
+	// the department check and the insert see the same snapshot
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		employeeData, err := uc.newEmployee(ctx, employee)
+		if err != nil {
+			return err
+		}
+
+		id, err = uc.repo.CreateEmployee(ctx, employeeData)
+		return err
+	})
+	return id, err
+}
+
+// newEmployee validates the create request and builds the employee to store
+func (uc *Usecase) newEmployee(ctx context.Context, employee *models.CreateEmployee) (*models.Employee, error) {
+	if err := validateCreateEmployee(employee); err != nil {
+		return nil, err
+	}
+	resource := auth.Resource{CompanyID: employee.CompanyID, DepartmentID: employee.DepartmentID}
+	if err := auth.Authorize(ctx, auth.ActionWriteEmployees, resource); err != nil {
+		return nil, err
+	}
+	if err := uc.checkDepartmentCompany(ctx, employee.DepartmentID, employee.CompanyID); err != nil {
+		return nil, err
+	}
+	if employee.ManagerID != nil {
+		if err := uc.checkManager(ctx, 0, *employee.ManagerID, employee.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.Employee{
 		Name:      employee.Name,
 		Surname:   employee.Surname,
 		Phone:     employee.Phone,
@@ -40,56 +81,359 @@ func (uc *Usecase) CreateEmployee(ctx context.Context, employee *models.CreateEm
 		Department: models.Department{
 			ID: employee.DepartmentID,
 		},
-	}
-
-	id, err := uc.repo.CreateEmployee(ctx, employeeData)
-	return id, err
+		ManagerID: employee.ManagerID,
+	}, nil
 }
-func (uc *Usecase) DeleteEmployee(ctx context.Context, id int32) error {
-	err := uc.repo.DeleteEmployee(ctx, id)
+func (uc *Usecase) DeleteEmployee(ctx context.Context, id int32, version *int32) error {
+	if err := uc.authorizeEmployee(ctx, auth.ActionWriteEmployees, id); err != nil {
+		return err
+	}
+	err := uc.repo.DeleteEmployee(ctx, id, version)
 	return err
 }
-func (uc *Usecase) GetListCompanyEmployees(ctx context.Context, companyID int32) ([]*models.Employee, error) {
-	listEmployees, err := uc.repo.GetListCompanyEmployees(ctx, companyID)
+func (uc *Usecase) GetEmployee(ctx context.Context, id int32) (*models.Employee, error) {
+	if err := uc.authorizeEmployee(ctx, auth.ActionReadEmployees, id); err != nil {
+		return nil, err
+	}
+	employee, err := uc.repo.GetEmployeeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	maskEmployees(ctx, employee)
+	return employee, nil
+}
+func (uc *Usecase) GetListCompanyEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error) {
+	if err := auth.Authorize(ctx, auth.ActionReadEmployees, auth.Resource{CompanyID: params.CompanyID}); err != nil {
+		return nil, err
+	}
+	listEmployees, err := uc.listEmployees(ctx, params)
 	return listEmployees, err
 }
 
-func (uc *Usecase) GetListDepartmentCompanyEmployees(ctx context.Context, departmentID int32) ([]*models.Employee, error) {
-	listEmployees, err := uc.repo.GetListDepartmentEmployees(ctx, departmentID)
+func (uc *Usecase) GetListDepartmentCompanyEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error) {
+	if err := uc.authorizeDepartment(ctx, auth.ActionReadEmployees, params.DepartmentID); err != nil {
+		return nil, err
+	}
+	// employees are listed per company, the department narrows the list down
+	department, err := uc.repo.GetDepartmentByID(ctx, params.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	params.CompanyID = department.CompanyID
+	listEmployees, err := uc.listEmployees(ctx, params)
 	return listEmployees, err
 }
 
-func (uc *Usecase) EditEmployee(ctx context.Context, employee *models.CreateEmployee) error {
-	employeeData := &models.Employee{
-		ID:        employee.ID,
-		Name:      employee.Name,
-		Surname:   employee.Surname,
-		Phone:     employee.Phone,
-		CompanyID: employee.CompanyID,
-		Passport: models.Passport{
-			Type:   employee.Passport.Type,
-			Number: employee.Passport.Number,
-		},
-		Department: models.Department{
-			ID: employee.DepartmentID,
-		},
+func (uc *Usecase) listEmployees(ctx context.Context, params *models.EmployeeListParams) (*models.EmployeeList, error) {
+	if params.Sort == "" {
+		params.Sort = models.SortByID
 	}
+	if err := validateEmployeeListParams(params); err != nil {
+		return nil, err
+	}
+
+	var cursor *models.EmployeeCursor
+	if params.After != "" {
+		var err error
+		cursor, err = decodeCursor(params.Sort, params.After)
+		if err != nil {
+			return nil, &errs.ValidationError{Fields: []errs.FieldError{{Field: "after", Message: err.Error()}}}
+		}
+	}
+
+	// one extra row tells whether there is a next page
+	page := *params
+	page.Limit++
+	employees, err := uc.repo.ListEmployees(ctx, &page, cursor)
+	if err != nil {
+		return nil, err
+	}
+	maskEmployees(ctx, employees...)
+
+	listEmployees := &models.EmployeeList{
+		Employees: employees,
+	}
+	// the count scans every matching employee, so it is not repeated for each page
+	if cursor == nil {
+		total, err := uc.repo.CountEmployees(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		listEmployees.Total = &total
+	}
+	if len(employees) > int(params.Limit) {
+		listEmployees.Employees = employees[:params.Limit]
+		listEmployees.NextCursor = encodeCursor(params.Sort, listEmployees.Employees[params.Limit-1])
+	}
+	return listEmployees, nil
+}
+
+func (uc *Usecase) SearchEmployees(ctx context.Context, params *models.EmployeeSearchParams) ([]*models.Employee, error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if err := validateEmployeeSearchParams(params); err != nil {
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, auth.ActionReadEmployees, auth.Resource{CompanyID: params.CompanyID}); err != nil {
+		return nil, err
+	}
+
+	listEmployees, err := uc.repo.SearchEmployees(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	maskEmployees(ctx, listEmployees...)
+	return listEmployees, nil
+}
 
-	if err := uc.repo.EditEmployee(ctx, employeeData); err != nil {
+func (uc *Usecase) EditEmployee(ctx context.Context, employee *models.UpdateEmployee) (int32, error) {
+	if err := validateEmployeePatch(employee); err != nil {
+		return 0, err
+	}
+
+	var version int32
+	// the department of the patched employee is checked in the transaction holding the row lock
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		version, err = uc.repo.EditEmployee(ctx, employee.ID, uc.patchEmployee(ctx, employee))
 		return err
+	})
+	return version, err
+}
+
+// patchEmployee returns the update applied to the locked employee row.
+// The caller has to be allowed to change the employee both where it is and where the patch moves it.
+func (uc *Usecase) patchEmployee(ctx context.Context, employee *models.UpdateEmployee) func(employeeData *models.Employee) error {
+	return func(employeeData *models.Employee) error {
+		if err := auth.Authorize(ctx, auth.ActionWriteEmployees, employeeResource(employeeData)); err != nil {
+			return err
+		}
+		if employee.Version != nil && *employee.Version != employeeData.Version {
+			return fmt.Errorf("%w: employee %d has version %d", errs.ErrPreconditionFailed, employeeData.ID, employeeData.Version)
+		}
+		applyEmployeePatch(employeeData, employee)
+		if err := auth.Authorize(ctx, auth.ActionWriteEmployees, employeeResource(employeeData)); err != nil {
+			return err
+		}
+
+		if employee.Passport != nil {
+			v := &validator{}
+			v.passport("passport", employeeData.Passport)
+			if err := v.err(); err != nil {
+				return err
+			}
+		}
+		if employee.CompanyID != nil || employee.DepartmentID != nil {
+			if err := uc.checkDepartmentCompany(ctx, employeeData.Department.ID, employeeData.CompanyID); err != nil {
+				return err
+			}
+		}
+		if (employee.CompanyID != nil || employee.ManagerID != nil) && employeeData.ManagerID != nil {
+			return uc.checkManager(ctx, employeeData.ID, *employeeData.ManagerID, employeeData.CompanyID)
+		}
+		return nil
 	}
-	return nil
+}
+func (uc *Usecase) GetArchivedEmployees(ctx context.Context, companyID int32, pagination *models.Pagination) ([]*models.Employee, error) {
+	if err := auth.Authorize(ctx, auth.ActionReadEmployees, auth.Resource{CompanyID: companyID}); err != nil {
+		return nil, err
+	}
+	listEmployees, err := uc.repo.ListArchivedEmployees(ctx, companyID, pagination)
+	if err != nil {
+		return nil, err
+	}
+	maskEmployees(ctx, listEmployees...)
+	return listEmployees, nil
+}
+func (uc *Usecase) RestoreEmployee(ctx context.Context, id int32) error {
+	if err := uc.authorizeEmployee(ctx, auth.ActionWriteEmployees, id); err != nil {
+		return err
+	}
+	err := uc.repo.RestoreEmployee(ctx, id)
+	return err
+}
+func (uc *Usecase) PurgeArchivedEmployees(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	if err := auth.Authorize(ctx, auth.ActionAdminister, auth.Resource{}); err != nil {
+		return 0, err
+	}
+	purged, err := uc.repo.PurgeEmployees(ctx, deletedBefore)
+	return purged, err
+}
+func (uc *Usecase) GetEmployeeHistory(ctx context.Context, id int32, pagination *models.Pagination) ([]*models.AuditRecord, error) {
+	if err := uc.authorizeEmployee(ctx, auth.ActionReadEmployees, id); err != nil {
+		return nil, err
+	}
+	history, err := uc.repo.ListAuditRecords(ctx, models.AuditEmployee, id, pagination)
+	return history, err
+}
+func (uc *Usecase) ExportEmployees(ctx context.Context, companyID int32, fn func(employee *models.Employee) error) error {
+	if err := auth.Authorize(ctx, auth.ActionReadEmployees, auth.Resource{CompanyID: companyID}); err != nil {
+		return err
+	}
+	// checked up front so that a missing company is reported before any row is written
+	if _, err := uc.repo.GetCompanyByID(ctx, companyID); err != nil {
+		return err
+	}
+	err := uc.repo.ExportEmployees(ctx, companyID, func(employee *models.Employee) error {
+		maskEmployees(ctx, employee)
+		return fn(employee)
+	})
+	return err
 }
 func (uc *Usecase) CreateCompany(ctx context.Context, name string) (int32, error) {
+	if err := auth.Authorize(ctx, auth.ActionAdminister, auth.Resource{}); err != nil {
+		return 0, err
+	}
+	if err := validateCompany(name); err != nil {
+		return 0, err
+	}
 	id, err := uc.repo.CreateCompany(ctx, name)
 	return id, err
 }
+func (uc *Usecase) GetCompanies(ctx context.Context, pagination *models.Pagination) ([]*models.Company, error) {
+	if err := auth.Authorize(ctx, auth.ActionAdminister, auth.Resource{}); err != nil {
+		return nil, err
+	}
+	companies, err := uc.repo.GetCompanies(ctx, pagination)
+	return companies, err
+}
+func (uc *Usecase) GetCompany(ctx context.Context, id int32) (*models.Company, error) {
+	if err := auth.Authorize(ctx, auth.ActionReadCompany, auth.Resource{CompanyID: id}); err != nil {
+		return nil, err
+	}
+	company, err := uc.repo.GetCompanyByID(ctx, id)
+	return company, err
+}
+func (uc *Usecase) EditCompany(ctx context.Context, company *models.Company) error {
+	if err := auth.Authorize(ctx, auth.ActionManageCompany, auth.Resource{CompanyID: company.ID}); err != nil {
+		return err
+	}
+	if err := validateCompany(company.Name); err != nil {
+		return err
+	}
+	err := uc.repo.EditCompany(ctx, company)
+	return err
+}
+func (uc *Usecase) DeleteCompany(ctx context.Context, id int32) error {
+	if err := auth.Authorize(ctx, auth.ActionAdminister, auth.Resource{}); err != nil {
+		return err
+	}
+	err := uc.repo.DeleteCompany(ctx, id)
+	return err
+}
 func (uc *Usecase) CreateDepartment(ctx context.Context, department *models.CreateDepartment) (int32, error) {
+	if err := validateCreateDepartment(department); err != nil {
+		return 0, err
+	}
+	if err := auth.Authorize(ctx, auth.ActionManageCompany, auth.Resource{CompanyID: department.CompanyID}); err != nil {
+		return 0, err
+	}
 	departmentDB := &models.Department{
 		Name:      department.Name,
 		Phone:     department.Phone,
 		CompanyID: department.CompanyID,
+		ParentID:  department.ParentID,
+	}
+	if department.ParentID == nil {
+		id, err := uc.repo.CreateDepartment(ctx, departmentDB)
+		return id, err
 	}
-	id, err := uc.repo.CreateDepartment(ctx, departmentDB)
+
+	var id int32
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		err := uc.checkParentDepartment(ctx, 0, *department.ParentID, department.CompanyID)
+		if err != nil {
+			return err
+		}
+		id, err = uc.repo.CreateDepartment(ctx, departmentDB)
+		return err
+	})
 	return id, err
 }
+func (uc *Usecase) GetDepartment(ctx context.Context, id int32) (*models.DepartmentInfo, error) {
+	if err := uc.authorizeDepartment(ctx, auth.ActionReadCompany, id); err != nil {
+		return nil, err
+	}
+	department, err := uc.repo.GetDepartmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return departmentInfo(department), nil
+}
+func (uc *Usecase) GetListCompanyDepartments(ctx context.Context, companyID int32) ([]*models.DepartmentInfo, error) {
+	if err := auth.Authorize(ctx, auth.ActionReadCompany, auth.Resource{CompanyID: companyID}); err != nil {
+		return nil, err
+	}
+	departments, err := uc.repo.GetCompanyDepartments(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	listDepartments := make([]*models.DepartmentInfo, len(departments))
+	for i, department := range departments {
+		listDepartments[i] = departmentInfo(department)
+	}
+	return listDepartments, nil
+}
+func (uc *Usecase) EditDepartment(ctx context.Context, department *models.CreateDepartment) error {
+	if err := validateEditDepartment(department); err != nil {
+		return err
+	}
+	if err := uc.authorizeDepartment(ctx, auth.ActionManageCompany, department.ID); err != nil {
+		return err
+	}
+	departmentDB := &models.Department{
+		ID:       department.ID,
+		Name:     department.Name,
+		Phone:    department.Phone,
+		ParentID: department.ParentID,
+	}
+	if department.ParentID == nil || *department.ParentID == 0 {
+		err := uc.repo.EditDepartment(ctx, departmentDB)
+		return err
+	}
+
+	// the parent is checked against the subtree the department has in the same transaction
+	err := uc.tx.Do(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.GetDepartmentByID(ctx, department.ID)
+		if err != nil {
+			return err
+		}
+		if err = uc.checkParentDepartment(ctx, current.ID, *department.ParentID, current.CompanyID); err != nil {
+			return err
+		}
+		return uc.repo.EditDepartment(ctx, departmentDB)
+	})
+	return err
+}
+func (uc *Usecase) DeleteDepartment(ctx context.Context, id int32) error {
+	if err := uc.authorizeDepartment(ctx, auth.ActionManageCompany, id); err != nil {
+		return err
+	}
+	err := uc.repo.DeleteDepartment(ctx, id)
+	return err
+}
+
+// checkDepartmentCompany makes sure the department exists and belongs to the company
+func (uc *Usecase) checkDepartmentCompany(ctx context.Context, departmentID, companyID int32) error {
+	department, err := uc.repo.GetDepartmentByID(ctx, departmentID)
+	if errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("%w: department %d", errs.ErrInvalidReference, departmentID)
+	}
+	if err != nil {
+		return err
+	}
+	if department.CompanyID != companyID {
+		return fmt.Errorf("%w: department %d, company %d", errs.ErrDepartmentCompanyMismatch, departmentID, companyID)
+	}
+	return nil
+}
+
+func departmentInfo(department *models.Department) *models.DepartmentInfo {
+	return &models.DepartmentInfo{
+		ID:        department.ID,
+		Name:      department.Name,
+		Phone:     department.Phone,
+		CompanyID: department.CompanyID,
+		ParentID:  department.ParentID,
+	}
+}
@@ -0,0 +1,419 @@
+package usecase
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// runInPlace is a transaction manager that runs the transaction body with the caller context
+func runInPlace(ctrl *gomock.Controller) *mockEmployee.MockTxManager {
+	tx := mockEmployee.NewMockTxManager(ctrl)
+	tx.EXPECT().Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	return tx
+}
+
+func TestUsecase_CreateEmployee(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockRepository)
+
+	newEmployee := &models.CreateEmployee{
+		Name:      "katya",
+		Surname:   "ivanova",
+		Phone:     "+79161234567",
+		CompanyID: 1,
+		Passport: models.Passport{
+			Type:   "РФ",
+			Number: "7878 898989",
+		},
+		DepartmentID: 2,
+	}
+
+	testTable := []struct {
+		name          string
+		mockBehavior  mockBehavior
+		expectedID    int32
+		expectedError error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(&models.Department{ID: 2, CompanyID: 1}, nil)
+				m.EXPECT().CreateEmployee(gomock.Any(), gomock.Any()).Return(int32(10), nil)
+			},
+			expectedID: 10,
+		},
+		{
+			name: "department of another company",
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(&models.Department{ID: 2, CompanyID: 3}, nil)
+			},
+			expectedError: errs.ErrDepartmentCompanyMismatch,
+		},
+		{
+			name: "department does not exist",
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(nil, errs.ErrNotFound)
+			},
+			expectedError: errs.ErrInvalidReference,
+		},
+	}
+
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			tt.mockBehavior(mockRepo)
+
+			uc := &Usecase{
+				repo: mockRepo,
+				tx:   runInPlace(ctrl),
+				log:  logger.SetupLogger(),
+			}
+
+			id, err := uc.CreateEmployee(context.Background(), newEmployee)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+		})
+	}
+}
+
+func TestUsecase_EditEmployee(t *testing.T) {
+	type mockBehavior func(m *mockEmployee.MockRepository)
+
+	stored := func() *models.Employee {
+		return &models.Employee{
+			ID:        1,
+			Name:      "katya",
+			Surname:   "ivanova",
+			Phone:     "+79161234567",
+			CompanyID: 1,
+			Passport: models.Passport{
+				Type:   "РФ",
+				Number: "7878 898989",
+			},
+			Department: models.Department{ID: 2},
+			Version:    4,
+		}
+	}
+	with := func(change func(e *models.Employee)) *models.Employee {
+		e := stored()
+		change(e)
+		return e
+	}
+
+	testTable := []struct {
+		name             string
+		input            *models.UpdateEmployee
+		mockBehavior     mockBehavior
+		expectedEmployee *models.Employee
+		expectedError    error
+	}{
+		{
+			name:             "name only",
+			input:            &models.UpdateEmployee{ID: 1, Name: lo.ToPtr("ivan")},
+			expectedEmployee: with(func(e *models.Employee) { e.Name = "ivan" }),
+		},
+		{
+			name:             "surname only",
+			input:            &models.UpdateEmployee{ID: 1, Surname: lo.ToPtr("petrova")},
+			expectedEmployee: with(func(e *models.Employee) { e.Surname = "petrova" }),
+		},
+		{
+			name:             "phone only",
+			input:            &models.UpdateEmployee{ID: 1, Phone: lo.ToPtr("+79990001122")},
+			expectedEmployee: with(func(e *models.Employee) { e.Phone = "+79990001122" }),
+		},
+		{
+			name:  "department of the same company",
+			input: &models.UpdateEmployee{ID: 1, DepartmentID: lo.ToPtr(int32(3))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(3)).Return(&models.Department{ID: 3, CompanyID: 1}, nil)
+			},
+			expectedEmployee: with(func(e *models.Employee) { e.Department.ID = 3 }),
+		},
+		{
+			name:  "company and department together",
+			input: &models.UpdateEmployee{ID: 1, CompanyID: lo.ToPtr(int32(5)), DepartmentID: lo.ToPtr(int32(7))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(7)).Return(&models.Department{ID: 7, CompanyID: 5}, nil)
+			},
+			expectedEmployee: with(func(e *models.Employee) {
+				e.CompanyID = 5
+				e.Department.ID = 7
+			}),
+		},
+		{
+			name:  "company changed without department",
+			input: &models.UpdateEmployee{ID: 1, CompanyID: lo.ToPtr(int32(5))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetDepartmentByID(gomock.Any(), int32(2)).Return(&models.Department{ID: 2, CompanyID: 1}, nil)
+			},
+			expectedError: errs.ErrDepartmentCompanyMismatch,
+		},
+		{
+			name:  "manager of the same company",
+			input: &models.UpdateEmployee{ID: 1, ManagerID: lo.ToPtr(int32(8))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetReportingChain(gomock.Any(), int32(8)).Return([]*models.Employee{{ID: 8, CompanyID: 1}, {ID: 9, CompanyID: 1}}, nil)
+			},
+			expectedEmployee: with(func(e *models.Employee) { e.ManagerID = lo.ToPtr(int32(8)) }),
+		},
+		{
+			name:  "manager reporting to the employee",
+			input: &models.UpdateEmployee{ID: 1, ManagerID: lo.ToPtr(int32(8))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetReportingChain(gomock.Any(), int32(8)).Return([]*models.Employee{{ID: 8, CompanyID: 1}, {ID: 1, CompanyID: 1}}, nil)
+			},
+			expectedError: errs.ErrHierarchyCycle,
+		},
+		{
+			name:  "manager of another company",
+			input: &models.UpdateEmployee{ID: 1, ManagerID: lo.ToPtr(int32(8))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetReportingChain(gomock.Any(), int32(8)).Return([]*models.Employee{{ID: 8, CompanyID: 2}}, nil)
+			},
+			expectedError: errs.ErrInvalidReference,
+		},
+		{
+			name:  "unknown manager",
+			input: &models.UpdateEmployee{ID: 1, ManagerID: lo.ToPtr(int32(8))},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().GetReportingChain(gomock.Any(), int32(8)).Return(nil, errs.ErrNotFound)
+			},
+			expectedError: errs.ErrInvalidReference,
+		},
+		{
+			name:             "manager removed with null",
+			input:            &models.UpdateEmployee{ID: 1, NullFields: []string{"manager_id"}},
+			expectedEmployee: stored(),
+		},
+		{
+			name:             "passport number only keeps type",
+			input:            &models.UpdateEmployee{ID: 1, Passport: &models.PassportPatch{Number: lo.ToPtr("1111 222222")}},
+			expectedEmployee: with(func(e *models.Employee) { e.Passport.Number = "1111 222222" }),
+		},
+		{
+			name:          "passport type only keeps number",
+			input:         &models.UpdateEmployee{ID: 1, Passport: &models.PassportPatch{Type: lo.ToPtr("загран")}},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name: "passport type and number",
+			input: &models.UpdateEmployee{ID: 1, Passport: &models.PassportPatch{
+				Type:   lo.ToPtr("загран"),
+				Number: lo.ToPtr("12 3456789"),
+			}},
+			expectedEmployee: with(func(e *models.Employee) {
+				e.Passport = models.Passport{Type: "загран", Number: "12 3456789"}
+			}),
+		},
+		{
+			name:             "matching version",
+			input:            &models.UpdateEmployee{ID: 1, Name: lo.ToPtr("ivan"), Version: lo.ToPtr(int32(4))},
+			expectedEmployee: with(func(e *models.Employee) { e.Name = "ivan" }),
+		},
+		{
+			name:          "stale version",
+			input:         &models.UpdateEmployee{ID: 1, Name: lo.ToPtr("ivan"), Version: lo.ToPtr(int32(3))},
+			expectedError: errs.ErrPreconditionFailed,
+		},
+		{
+			name:          "empty name",
+			input:         &models.UpdateEmployee{ID: 1, Name: lo.ToPtr("")},
+			mockBehavior:  func(m *mockEmployee.MockRepository) {},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name:          "explicit null",
+			input:         &models.UpdateEmployee{ID: 1, NullFields: []string{"phone"}},
+			mockBehavior:  func(m *mockEmployee.MockRepository) {},
+			expectedError: errs.ErrValidation,
+		},
+		{
+			name:  "employee not found",
+			input: &models.UpdateEmployee{ID: 1, Name: lo.ToPtr("ivan")},
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().EditEmployee(gomock.Any(), int32(1), gomock.Any()).Return(int32(0), errs.ErrNotFound)
+			},
+			expectedError: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			if tt.mockBehavior != nil {
+				tt.mockBehavior(mockRepo)
+			}
+			// the repository hands the locked row to the callback, like the postgres implementation
+			var updated *models.Employee
+			mockRepo.EXPECT().EditEmployee(gomock.Any(), int32(1), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, id int32, update func(*models.Employee) error) (int32, error) {
+					employee := stored()
+					if err := update(employee); err != nil {
+						return 0, err
+					}
+					updated = employee
+					return employee.Version + 1, nil
+				}).MaxTimes(1)
+
+			uc := &Usecase{
+				repo: mockRepo,
+				tx:   runInPlace(ctrl),
+				log:  logger.SetupLogger(),
+			}
+
+			version, err := uc.EditEmployee(context.Background(), tt.input)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int32(5), version)
+			assert.Equal(t, tt.expectedEmployee, updated)
+		})
+	}
+}
+
+func TestUsecase_GetListCompanyEmployees(t *testing.T) {
+	employees := []*models.Employee{
+		{ID: 1, Surname: "alekseev"},
+		{ID: 7, Surname: "ivanov"},
+		{ID: 3, Surname: "petrov"},
+	}
+
+	t.Run("next page exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		params := &models.EmployeeListParams{CompanyID: 1, Limit: 2, Sort: models.SortBySurname}
+		mockRepo.EXPECT().ListEmployees(gomock.Any(), &models.EmployeeListParams{
+			CompanyID: 1, Limit: 3, Sort: models.SortBySurname,
+		}, nil).Return(employees, nil)
+		mockRepo.EXPECT().CountEmployees(gomock.Any(), params).Return(int64(10), nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		list, err := uc.GetListCompanyEmployees(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Len(t, list.Employees, 2)
+		assert.Equal(t, lo.ToPtr(int64(10)), list.Total)
+
+		cursor, err := decodeCursor(models.SortBySurname, list.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.EmployeeCursor{ID: 7, Text: "ivanov"}, cursor)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		params := &models.EmployeeListParams{CompanyID: 1, Limit: 5}
+		mockRepo.EXPECT().ListEmployees(gomock.Any(), gomock.Any(), nil).Return(employees, nil)
+		mockRepo.EXPECT().CountEmployees(gomock.Any(), params).Return(int64(3), nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		list, err := uc.GetListCompanyEmployees(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Len(t, list.Employees, 3)
+		assert.Empty(t, list.NextCursor)
+		assert.Equal(t, models.SortByID, params.Sort)
+	})
+
+	t.Run("cursor page is not counted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().ListEmployees(gomock.Any(), gomock.Any(), &models.EmployeeCursor{ID: 7, Text: "ivanov"}).
+			Return(employees[2:], nil)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		list, err := uc.GetListCompanyEmployees(context.Background(), &models.EmployeeListParams{
+			CompanyID: 1,
+			Limit:     2,
+			Sort:      models.SortBySurname,
+			After:     encodeCursor(models.SortBySurname, employees[1]),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, list.Employees, 1)
+		assert.Nil(t, list.Total)
+	})
+
+	t.Run("cursor of another sort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := &Usecase{repo: mockEmployee.NewMockRepository(ctrl), tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetListCompanyEmployees(context.Background(), &models.EmployeeListParams{
+			CompanyID: 1,
+			Limit:     2,
+			Sort:      models.SortByName,
+			After:     encodeCursor(models.SortBySurname, employees[0]),
+		})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+
+	t.Run("unknown sort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := &Usecase{repo: mockEmployee.NewMockRepository(ctrl), tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		_, err := uc.GetListCompanyEmployees(context.Background(), &models.EmployeeListParams{CompanyID: 1, Limit: 2, Sort: "phone"})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
+
+func TestUsecase_ExportEmployees(t *testing.T) {
+	t.Run("company not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetCompanyByID(gomock.Any(), int32(3)).Return(nil, errs.ErrNotFound)
+
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		err := uc.ExportEmployees(context.Background(), 3, func(*models.Employee) error { return nil })
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("streams from repo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mockEmployee.NewMockRepository(ctrl)
+		mockRepo.EXPECT().GetCompanyByID(gomock.Any(), int32(3)).Return(&models.Company{ID: 3}, nil)
+		mockRepo.EXPECT().ExportEmployees(gomock.Any(), int32(3), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int32, fn func(*models.Employee) error) error {
+				return fn(&models.Employee{ID: 1})
+			})
+
+		var exported []int32
+		uc := &Usecase{repo: mockRepo, tx: runInPlace(ctrl), log: logger.SetupLogger()}
+		err := uc.ExportEmployees(context.Background(), 3, func(employee *models.Employee) error {
+			exported = append(exported, employee.ID)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{1}, exported)
+	})
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"employees/internal/models"
+	"employees/internal/pkg/errs"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	maxNameLength      = 255
+	minSearchQueryLen  = 2
+	maxBatchOperations = 100
+)
+
+// phonePattern accepts E.164-style numbers: optional plus, no leading zero, 7 to 15 digits
+var phonePattern = regexp.MustCompile(`^\+?[1-9]\d{6,14}$`)
+
+// passportNumberPatterns holds the allowed passport types and the number format of each
+var passportNumberPatterns = map[string]*regexp.Regexp{
+	"РФ":     regexp.MustCompile(`^\d{4} ?\d{6}$`),
+	"загран": regexp.MustCompile(`^\d{2} ?\d{7}$`),
+	"РБ":     regexp.MustCompile(`^[A-ZА-Я]{2}\d{7}$`),
+}
+
+// countryPattern accepts ISO 3166-1 alpha-2 country codes
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+var employeeSorts = map[string]bool{
+	models.SortByID:        true,
+	models.SortByName:      true,
+	models.SortBySurname:   true,
+	models.SortByCreatedAt: true,
+}
+
+type validator struct {
+	fields []errs.FieldError
+}
+
+func (v *validator) add(field, message string) {
+	v.fields = append(v.fields, errs.FieldError{Field: field, Message: message})
+}
+
+func (v *validator) name(field, value string) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.add(field, "is required")
+	case len([]rune(value)) > maxNameLength:
+		v.add(field, "is too long")
+	}
+}
+
+func (v *validator) phone(field, value string) {
+	switch {
+	case value == "":
+		v.add(field, "is required")
+	case !phonePattern.MatchString(value):
+		v.add(field, "must be a phone number in E.164 format")
+	}
+}
+
+func (v *validator) id(field string, value int32) {
+	if value <= 0 {
+		v.add(field, "must be a positive id")
+	}
+}
+
+func (v *validator) passport(field string, passport models.Passport) {
+	v.documentNumber(field+".type", field+".number", passport.Type, passport.Number)
+}
+
+// documentNumber checks the number against the format of the document type
+func (v *validator) documentNumber(typeField, numberField, documentType, number string) {
+	pattern, ok := passportNumberPatterns[documentType]
+	if !ok {
+		v.add(typeField, "unknown passport type")
+		return
+	}
+	switch {
+	case number == "":
+		v.add(numberField, "is required")
+	case !pattern.MatchString(number):
+		v.add(numberField, "does not match the format of passport type "+documentType)
+	}
+}
+
+// date checks an optional date, it reports whether the value is a valid date
+func (v *validator) date(field, value string) bool {
+	if value == "" {
+		return false
+	}
+	if _, err := time.Parse(models.DateLayout, value); err != nil {
+		v.add(field, "must be a date in YYYY-MM-DD format")
+		return false
+	}
+	return true
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &errs.ValidationError{Fields: v.fields}
+}
+
+func validateCreateEmployee(employee *models.CreateEmployee) error {
+	v := &validator{}
+	v.name("name", employee.Name)
+	v.name("surname", employee.Surname)
+	v.phone("phone", employee.Phone)
+	v.id("company_id", employee.CompanyID)
+	v.id("department_id", employee.DepartmentID)
+	if employee.ManagerID != nil {
+		v.id("manager_id", *employee.ManagerID)
+	}
+	v.passport("passport", employee.Passport)
+	return v.err()
+}
+func validateImportEmployee(employee *models.ImportEmployee, departmentIDs map[string]int32) []errs.FieldError {
+	v := &validator{}
+	v.name("name", employee.Name)
+	v.name("surname", employee.Surname)
+	v.phone("phone", employee.Phone)
+	if _, ok := departmentIDs[employee.Department]; !ok {
+		v.add("department", "not found in company")
+	}
+	v.passport("passport", employee.Passport)
+	return v.fields
+}
+
+// validateEmployeePatch checks only the fields present in a merge patch.
+// Passport type and number are checked against each other once the patch is applied.
+func validateEmployeePatch(employee *models.UpdateEmployee) error {
+	v := &validator{}
+	for _, field := range employee.NullFields {
+		if field != removableEmployeeField {
+			v.add(field, "cannot be null")
+		}
+	}
+	if employee.Name != nil {
+		v.name("name", *employee.Name)
+	}
+	if employee.Surname != nil {
+		v.name("surname", *employee.Surname)
+	}
+	if employee.Phone != nil {
+		v.phone("phone", *employee.Phone)
+	}
+	if employee.CompanyID != nil {
+		v.id("company_id", *employee.CompanyID)
+	}
+	if employee.DepartmentID != nil {
+		v.id("department_id", *employee.DepartmentID)
+	}
+	if employee.ManagerID != nil {
+		v.id("manager_id", *employee.ManagerID)
+	}
+	if employee.Passport != nil && employee.Passport.Type != nil {
+		if _, ok := passportNumberPatterns[*employee.Passport.Type]; !ok {
+			v.add("passport.type", "unknown passport type")
+		}
+	}
+	return v.err()
+}
+
+func validateCompany(name string) error {
+	v := &validator{}
+	v.name("name", name)
+	return v.err()
+}
+
+func validateCreateDepartment(department *models.CreateDepartment) error {
+	v := &validator{}
+	v.name("name", department.Name)
+	v.phone("phone", department.Phone)
+	v.id("company_id", department.CompanyID)
+	if department.ParentID != nil {
+		v.id("parent_department_id", *department.ParentID)
+	}
+	return v.err()
+}
+
+// validateEditDepartment checks only the fields present in a partial update
+func validateEditDepartment(department *models.CreateDepartment) error {
+	v := &validator{}
+	if department.Name != "" {
+		v.name("name", department.Name)
+	}
+	if department.Phone != "" {
+		v.phone("phone", department.Phone)
+	}
+	// 0 detaches the department from its parent
+	if department.ParentID != nil && *department.ParentID != 0 {
+		v.id("parent_department_id", *department.ParentID)
+	}
+	return v.err()
+}
+
+func validateEmployeeListParams(params *models.EmployeeListParams) error {
+	v := &validator{}
+	if !employeeSorts[params.Sort] {
+		v.add("sort", "must be one of id, name, surname, created_at")
+	}
+	if params.Limit <= 0 {
+		v.add("limit", "must be positive")
+	}
+	if params.PassportType != "" {
+		if _, ok := passportNumberPatterns[params.PassportType]; !ok {
+			v.add("passport_type", "unknown passport type")
+		}
+	}
+	return v.err()
+}
+
+func validateEmployeeSearchParams(params *models.EmployeeSearchParams) error {
+	v := &validator{}
+	if len([]rune(params.Query)) < minSearchQueryLen {
+		v.add("q", "must contain at least 2 characters")
+	}
+	if params.Limit <= 0 {
+		v.add("limit", "must be positive")
+	}
+	return v.err()
+}
+
+func validateBatchRequest(request *models.BatchRequest) error {
+	v := &validator{}
+	if request.Mode != models.BatchAtomic && request.Mode != models.BatchBestEffort {
+		v.add("mode", "must be atomic or best_effort")
+	}
+	switch {
+	case len(request.Operations) == 0:
+		v.add("operations", "is required")
+	case len(request.Operations) > maxBatchOperations:
+		v.add("operations", fmt.Sprintf("must contain at most %d operations", maxBatchOperations))
+	}
+	for i, operation := range request.Operations {
+		if operation == nil {
+			v.add(fmt.Sprintf("operations[%d]", i), "is required")
+		}
+	}
+	return v.err()
+}
+
+func validateBatchOperation(operation *models.BatchOperation) error {
+	v := &validator{}
+	switch operation.Op {
+	case models.BatchCreate:
+		if operation.Create == nil {
+			v.add("data", "is required")
+		}
+	case models.BatchUpdate:
+		v.id("id", operation.ID)
+		if operation.Update == nil {
+			v.add("data", "is required")
+		}
+	case models.BatchDelete:
+		v.id("id", operation.ID)
+	default:
+		v.add("op", "must be create, update or delete")
+	}
+	return v.err()
+}
+
+func validateTransfer(transfer *models.Transfer, today string) error {
+	v := &validator{}
+	v.id("department_id", transfer.DepartmentID)
+	if _, err := time.Parse(models.DateLayout, transfer.EffectiveDate); err != nil {
+		v.add("effective_date", "must be a date in YYYY-MM-DD format")
+	} else if transfer.EffectiveDate < today {
+		v.add("effective_date", "must not be in the past")
+	}
+	if len([]rune(transfer.Reason)) > maxNameLength {
+		v.add("reason", "is too long")
+	}
+	return v.err()
+}
+
+func validateIdentityDocument(document *models.IdentityDocument) error {
+	v := &validator{}
+	v.documentNumber("type", "number", document.Type, document.Number)
+	if len([]rune(document.Issuer)) > maxNameLength {
+		v.add("issuer", "is too long")
+	}
+	issued := v.date("issue_date", document.IssueDate)
+	expires := v.date("expiry_date", document.ExpiryDate)
+	// dates in DateLayout compare as strings
+	if issued && expires && document.ExpiryDate < document.IssueDate {
+		v.add("expiry_date", "must not be before issue_date")
+	}
+	if document.Country != "" && !countryPattern.MatchString(document.Country) {
+		v.add("country", "must be an ISO 3166-1 alpha-2 code")
+	}
+	return v.err()
+}
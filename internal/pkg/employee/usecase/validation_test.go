@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"employees/internal/models"
+	"employees/internal/pkg/errs"
+	"errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateCreateEmployee(t *testing.T) {
+	validEmployee := func() *models.CreateEmployee {
+		return &models.CreateEmployee{
+			Name:      "katya",
+			Surname:   "ivanova",
+			Phone:     "+79161234567",
+			CompanyID: 1,
+			Passport: models.Passport{
+				Type:   "РФ",
+				Number: "7878 898989",
+			},
+			DepartmentID: 1,
+		}
+	}
+
+	testTable := []struct {
+		name           string
+		modify         func(e *models.CreateEmployee)
+		expectedFields []string
+	}{
+		{
+			name:   "ok",
+			modify: func(e *models.CreateEmployee) {},
+		},
+		{
+			name: "ok: belarus passport",
+			modify: func(e *models.CreateEmployee) {
+				e.Passport = models.Passport{Type: "РБ", Number: "MP1234567"}
+			},
+		},
+		{
+			name: "empty names",
+			modify: func(e *models.CreateEmployee) {
+				e.Name = " "
+				e.Surname = ""
+			},
+			expectedFields: []string{"name", "surname"},
+		},
+		{
+			name: "malformed phone",
+			modify: func(e *models.CreateEmployee) {
+				e.Phone = "8-916-123"
+			},
+			expectedFields: []string{"phone"},
+		},
+		{
+			name: "missing references",
+			modify: func(e *models.CreateEmployee) {
+				e.CompanyID = 0
+				e.DepartmentID = -1
+			},
+			expectedFields: []string{"company_id", "department_id"},
+		},
+		{
+			name: "unknown passport type",
+			modify: func(e *models.CreateEmployee) {
+				e.Passport.Type = "unknown"
+			},
+			expectedFields: []string{"passport.type"},
+		},
+		{
+			name: "passport number does not match type",
+			modify: func(e *models.CreateEmployee) {
+				e.Passport.Number = "12"
+			},
+			expectedFields: []string{"passport.number"},
+		},
+	}
+
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			employee := validEmployee()
+			tt.modify(employee)
+
+			err := validateCreateEmployee(employee)
+			if tt.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, errs.ErrValidation)
+			var validationErr *errs.ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+			fields := make([]string, len(validationErr.Fields))
+			for i, field := range validationErr.Fields {
+				fields[i] = field.Field
+			}
+			assert.Equal(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestValidateEmployeePatch(t *testing.T) {
+	assert.NoError(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1}))
+	assert.NoError(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1, Phone: lo.ToPtr("+375291234567")}))
+	assert.ErrorIs(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1, Phone: lo.ToPtr("abc")}), errs.ErrValidation)
+	assert.ErrorIs(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1, Name: lo.ToPtr("")}), errs.ErrValidation)
+	assert.ErrorIs(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1, Passport: &models.PassportPatch{Type: lo.ToPtr("xx")}}), errs.ErrValidation)
+	assert.NoError(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1, NullFields: []string{"manager_id"}}))
+	assert.ErrorIs(t, validateEmployeePatch(&models.UpdateEmployee{ID: 1, ManagerID: lo.ToPtr(int32(0))}), errs.ErrValidation)
+
+	err := validateEmployeePatch(&models.UpdateEmployee{ID: 1, NullFields: []string{"name", "passport.number"}})
+	var validationErr *errs.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []errs.FieldError{
+		{Field: "name", Message: "cannot be null"},
+		{Field: "passport.number", Message: "cannot be null"},
+	}, validationErr.Fields)
+}
+
+func TestValidateCreateDepartment(t *testing.T) {
+	assert.NoError(t, validateCreateDepartment(&models.CreateDepartment{Name: "dev", Phone: "84951234567", CompanyID: 1}))
+
+	err := validateCreateDepartment(&models.CreateDepartment{Name: "", Phone: "+123456789012345678", CompanyID: 0})
+	var validationErr *errs.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Fields, 3)
+}
+
+func TestValidateCompany(t *testing.T) {
+	assert.NoError(t, validateCompany("company"))
+	assert.ErrorIs(t, validateCompany(""), errs.ErrValidation)
+}
+
+func TestValidateIdentityDocument(t *testing.T) {
+	assert.NoError(t, validateIdentityDocument(&models.IdentityDocument{Type: "РФ", Number: "4510 123456"}))
+	assert.NoError(t, validateIdentityDocument(&models.IdentityDocument{
+		Type: "загран", Number: "75 1234567", IssueDate: "2020-01-15", ExpiryDate: "2030-01-15", Country: "RU",
+	}))
+
+	err := validateIdentityDocument(&models.IdentityDocument{
+		Type: "РБ", Number: "4510 123456", IssueDate: "2020-01-15", ExpiryDate: "2019-12-31", Country: "rus",
+	})
+	var validationErr *errs.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []errs.FieldError{
+		{Field: "number", Message: "does not match the format of passport type РБ"},
+		{Field: "expiry_date", Message: "must not be before issue_date"},
+		{Field: "country", Message: "must be an ISO 3166-1 alpha-2 code"},
+	}, validationErr.Fields)
+
+	assert.ErrorIs(t, validateIdentityDocument(&models.IdentityDocument{Type: "РФ", Number: "4510 123456", IssueDate: "15.01.2020"}), errs.ErrValidation)
+}
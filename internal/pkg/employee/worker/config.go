@@ -0,0 +1,20 @@
+package worker
+
+import "time"
+
+type PurgeConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"PURGE_ENABLED" env-default:"true"`
+	Interval  time.Duration `yaml:"interval" env:"PURGE_INTERVAL" env-default:"1h"`
+	Retention time.Duration `yaml:"retention" env:"PURGE_RETENTION" env-default:"8760h"`
+}
+
+type TransferConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"TRANSFERS_ENABLED" env-default:"true"`
+	Interval time.Duration `yaml:"interval" env:"TRANSFERS_INTERVAL" env-default:"5m"`
+}
+
+type EncryptionConfig struct {
+	// OnStart seals the document numbers left in plaintext when the server starts
+	OnStart   bool  `yaml:"onStart" env:"ENCRYPTION_ON_START" env-default:"true"`
+	BatchSize int32 `yaml:"batchSize" env:"ENCRYPTION_BATCH_SIZE" env-default:"500"`
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"employees/internal/pkg/employee"
+	"go.uber.org/fx"
+	"log/slog"
+	"time"
+)
+
+type EncryptionParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    EncryptionConfig
+	Repo      employee.Repository
+	Logger    *slog.Logger
+}
+
+// RunEncryption seals the document numbers stored before encryption was introduced in the background
+// of a starting server, the numbers left in plaintext are still readable until then
+func RunEncryption(p EncryptionParams) {
+	if !p.Config.OnStart {
+		p.Logger.Info("encryption of identity documents on start is disabled")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				_ = encryptDocuments(ctx, p)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// EncryptDocuments is the encrypt-documents command, it seals every document number left in plaintext
+func EncryptDocuments(p EncryptionParams) error {
+	return encryptDocuments(context.Background(), p)
+}
+
+func encryptDocuments(ctx context.Context, p EncryptionParams) error {
+	start := time.Now()
+	sealed, err := p.Repo.EncryptIdentityDocuments(ctx, p.Config.BatchSize)
+	if err != nil {
+		p.Logger.Error("encrypt identity documents", "sealed", sealed, "error", err.Error())
+		return err
+	}
+	p.Logger.Info("encrypted identity documents", "count", sealed, "duration", time.Since(start))
+	return nil
+}
@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/logger"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestEncryptDocuments(t *testing.T) {
+	testTable := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "ok",
+		},
+		{
+			name: "database unavailable",
+			err:  fmt.Errorf("connection refused"),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			mockRepo.EXPECT().EncryptIdentityDocuments(gomock.Any(), int32(100)).Return(250, tt.err)
+
+			err := encryptDocuments(context.Background(), EncryptionParams{
+				Config: EncryptionConfig{OnStart: true, BatchSize: 100},
+				Repo:   mockRepo,
+				Logger: logger.SetupLogger(),
+			})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
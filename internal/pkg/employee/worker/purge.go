@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"employees/internal/pkg/employee"
+	"go.uber.org/fx"
+	"log/slog"
+	"time"
+)
+
+type PurgeParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    PurgeConfig
+	Uc        employee.Usecase
+	Logger    *slog.Logger
+}
+
+type Purger struct {
+	cfg PurgeConfig
+	uc  employee.Usecase
+	log *slog.Logger
+	now func() time.Time
+}
+
+// RunPurge periodically hard-deletes employees archived longer than the retention period
+func RunPurge(p PurgeParams) {
+	if !p.Config.Enabled {
+		p.Logger.Info("purge of archived employees is disabled")
+		return
+	}
+
+	purger := &Purger{
+		cfg: p.Config,
+		uc:  p.Uc,
+		log: p.Logger,
+		now: time.Now,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				purger.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (p *Purger) run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	deletedBefore := p.now().Add(-p.cfg.Retention)
+	purged, err := p.uc.PurgeArchivedEmployees(ctx, deletedBefore)
+	if err != nil {
+		p.log.ErrorContext(ctx, "purge archived employees", "error", err.Error())
+		return
+	}
+	p.log.InfoContext(ctx, "purged archived employees", "count", purged, "deleted_before", deletedBefore)
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/logger"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name      string
+		retention time.Duration
+		err       error
+	}{
+		{
+			name:      "ok",
+			retention: 24 * time.Hour,
+		},
+		{
+			name:      "database unavailable",
+			retention: 30 * 24 * time.Hour,
+			err:       fmt.Errorf("connection refused"),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mockEmployee.NewMockUsecase(ctrl)
+			mockUsecase.EXPECT().PurgeArchivedEmployees(gomock.Any(), now.Add(-tt.retention)).Return(int64(3), tt.err)
+
+			purger := &Purger{
+				cfg: PurgeConfig{Enabled: true, Interval: time.Hour, Retention: tt.retention},
+				uc:  mockUsecase,
+				log: logger.SetupLogger(),
+				now: func() time.Time { return now },
+			}
+			purger.purge(context.Background())
+		})
+	}
+}
+
+func TestPurger_RunStopsWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockUsecase := mockEmployee.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().PurgeArchivedEmployees(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, time.Time) (int64, error) {
+			cancel()
+			return 0, nil
+		})
+
+	purger := &Purger{
+		cfg: PurgeConfig{Enabled: true, Interval: time.Hour, Retention: time.Hour},
+		uc:  mockUsecase,
+		log: logger.SetupLogger(),
+		now: time.Now,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		purger.run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "purger did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"employees/internal/models"
+	"time"
+)
+
+// RotateKeys is the rotate-keys command, it reseals every document number with the active key.
+// Numbers left in plaintext are sealed first. The servers keep opening numbers under the old keys
+// as long as those stay configured, the old keys can be removed once the command has finished.
+func RotateKeys(p EncryptionParams) error {
+	ctx := context.Background()
+	if err := encryptDocuments(ctx, p); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err := p.Repo.RotateIdentityDocuments(ctx, p.Config.BatchSize, func(rotation *models.KeyRotation) {
+		p.Logger.Info("rotate identity document keys",
+			"key_id", rotation.KeyID,
+			"rotated", rotation.Rotated,
+			"total", rotation.Total,
+			"last_document_id", rotation.LastDocumentID,
+			"finished", rotation.Finished,
+		)
+	})
+	if err != nil {
+		p.Logger.Error("rotate identity document keys", "error", err.Error())
+		return err
+	}
+	p.Logger.Info("rotated identity document keys", "duration", time.Since(start))
+	return nil
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"employees/internal/models"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/logger"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestRotateKeys(t *testing.T) {
+	testTable := []struct {
+		name          string
+		mockBehavior  func(m *mockEmployee.MockRepository)
+		expectedError error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				gomock.InOrder(
+					m.EXPECT().EncryptIdentityDocuments(gomock.Any(), int32(100)).Return(0, nil),
+					m.EXPECT().RotateIdentityDocuments(gomock.Any(), int32(100), gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ int32, progress func(rotation *models.KeyRotation)) error {
+							progress(&models.KeyRotation{KeyID: "new", Rotated: 100, Total: 150, LastDocumentID: 120})
+							progress(&models.KeyRotation{KeyID: "new", Rotated: 150, Total: 150, LastDocumentID: 170, Finished: true})
+							return nil
+						}),
+				)
+			},
+		},
+		{
+			name: "plaintext documents are not sealed",
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().EncryptIdentityDocuments(gomock.Any(), int32(100)).Return(0, fmt.Errorf("connection refused"))
+			},
+			expectedError: fmt.Errorf("connection refused"),
+		},
+		{
+			name: "rotation interrupted",
+			mockBehavior: func(m *mockEmployee.MockRepository) {
+				m.EXPECT().EncryptIdentityDocuments(gomock.Any(), int32(100)).Return(0, nil)
+				m.EXPECT().RotateIdentityDocuments(gomock.Any(), int32(100), gomock.Any()).Return(fmt.Errorf("connection refused"))
+			},
+			expectedError: fmt.Errorf("connection refused"),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockEmployee.NewMockRepository(ctrl)
+			tt.mockBehavior(mockRepo)
+
+			err := RotateKeys(EncryptionParams{
+				Config: EncryptionConfig{BatchSize: 100},
+				Repo:   mockRepo,
+				Logger: logger.SetupLogger(),
+			})
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"employees/internal/pkg/employee"
+	"go.uber.org/fx"
+	"log/slog"
+	"time"
+)
+
+type TransferParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    TransferConfig
+	Uc        employee.Usecase
+	Logger    *slog.Logger
+}
+
+type Transferer struct {
+	cfg TransferConfig
+	uc  employee.Usecase
+	log *slog.Logger
+	now func() time.Time
+}
+
+// RunTransfers periodically applies scheduled department transfers that became effective
+func RunTransfers(p TransferParams) {
+	if !p.Config.Enabled {
+		p.Logger.Info("scheduled transfers are disabled")
+		return
+	}
+
+	transferer := &Transferer{
+		cfg: p.Config,
+		uc:  p.Uc,
+		log: p.Logger,
+		now: time.Now,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				transferer.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (t *Transferer) run(ctx context.Context) {
+	ticker := time.NewTicker(t.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		t.transfer(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Transferer) transfer(ctx context.Context) {
+	applied, err := t.uc.ApplyDueTransfers(ctx, t.now())
+	if err != nil {
+		t.log.ErrorContext(ctx, "apply scheduled transfers", "error", err.Error())
+		return
+	}
+	if applied > 0 {
+		t.log.InfoContext(ctx, "applied scheduled transfers", "count", applied)
+	}
+}
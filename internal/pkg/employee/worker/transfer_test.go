@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	mockEmployee "employees/internal/pkg/employee/mocks"
+	"employees/internal/pkg/logger"
+	"fmt"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestTransferer_Transfer(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name    string
+		applied int
+		err     error
+	}{
+		{
+			name:    "ok",
+			applied: 2,
+		},
+		{
+			name: "database unavailable",
+			err:  fmt.Errorf("connection refused"),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mockEmployee.NewMockUsecase(ctrl)
+			mockUsecase.EXPECT().ApplyDueTransfers(gomock.Any(), now).Return(tt.applied, tt.err)
+
+			transferer := &Transferer{
+				cfg: TransferConfig{Enabled: true, Interval: time.Minute},
+				uc:  mockUsecase,
+				log: logger.SetupLogger(),
+				now: func() time.Time { return now },
+			}
+			transferer.transfer(context.Background())
+		})
+	}
+}
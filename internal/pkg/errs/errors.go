@@ -0,0 +1,58 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrConflict         = errors.New("already exists")
+	ErrInvalidReference = errors.New("invalid reference")
+	ErrValidation       = errors.New("validation failed")
+
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+
+	ErrDepartmentCompanyMismatch = fmt.Errorf("%w: department belongs to another company", ErrInvalidReference)
+	ErrHierarchyCycle            = fmt.Errorf("%w: hierarchy cycle", ErrInvalidReference)
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every invalid field of a request. It matches ErrValidation with errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = field.Field + ": " + field.Message
+	}
+	return ErrValidation.Error() + ": " + strings.Join(fields, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// ForbiddenError tells why the caller may not perform an operation. It matches ErrForbidden with errors.Is.
+type ForbiddenError struct {
+	Reason string
+}
+
+func (e *ForbiddenError) Error() string {
+	return ErrForbidden.Error() + ": " + e.Reason
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return ErrForbidden
+}
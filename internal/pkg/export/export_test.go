@@ -0,0 +1,71 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+var createdAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func writeTable(t *testing.T, format string) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, []string{"id", "name", "created_at"})
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteRow([]any{int32(1), "katya, \"kate\"", createdAt}))
+	assert.NoError(t, w.WriteRow([]any{int32(2), "<ivan> & co", createdAt}))
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestCSVWriter(t *testing.T) {
+	assert.Equal(t, `id,name,created_at
+1,"katya, ""kate""",2024-03-01T12:00:00Z
+2,<ivan> & co,2024-03-01T12:00:00Z
+`, string(writeTable(t, FormatCSV)))
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	assert.Equal(t, `{"id":1,"name":"katya, \"kate\"","created_at":"2024-03-01T12:00:00Z"}
+{"id":2,"name":"<ivan> & co","created_at":"2024-03-01T12:00:00Z"}
+`, string(writeTable(t, FormatNDJSON)))
+}
+
+func TestXLSXWriter(t *testing.T) {
+	data := writeTable(t, FormatXLSX)
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		files[f.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "xl/workbook.xml")
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"],
+		`<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"],
+		`<row r="3"><c r="A3"><v>2</v></c><c r="B3" t="inlineStr"><is><t xml:space="preserve">&lt;ivan&gt; &amp; co</t></is></c>`)
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	_, err := NewWriter("pdf", io.Discard, []string{"id"})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	keys [][]byte
+	buf  bytes.Buffer
+	enc  *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer, columns []string) *ndjsonWriter {
+	writer := &ndjsonWriter{
+		w:    bufio.NewWriter(w),
+		keys: make([][]byte, len(columns)),
+	}
+	writer.enc = json.NewEncoder(&writer.buf)
+	writer.enc.SetEscapeHTML(false)
+
+	for i, column := range columns {
+		writer.keys[i], _ = json.Marshal(column)
+	}
+	return writer
+}
+
+// WriteRow writes one object per line, keys keep the column order
+func (n *ndjsonWriter) WriteRow(values []any) error {
+	_ = n.w.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			_ = n.w.WriteByte(',')
+		}
+		if t, ok := value.(time.Time); ok {
+			value = t.Format(time.RFC3339)
+		}
+		n.buf.Reset()
+		if err := n.enc.Encode(value); err != nil {
+			return err
+		}
+		_, _ = n.w.Write(n.keys[i])
+		_ = n.w.WriteByte(':')
+		// Encode terminates every value with a newline
+		_, _ = n.w.Write(bytes.TrimSuffix(n.buf.Bytes(), []byte{'\n'}))
+	}
+	_, err := n.w.WriteString("}\n")
+	return err
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
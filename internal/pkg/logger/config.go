@@ -0,0 +1,15 @@
+package logger
+
+// formats of the log lines
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type Config struct {
+	// Level is one of debug, info, warn and error
+	Level  string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" env-default:"text"`
+	// Output is stdout, stderr or the path of a file the lines are appended to
+	Output string `yaml:"output" env:"LOG_OUTPUT" env-default:"stdout"`
+}
@@ -1,13 +1,63 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+	"go.uber.org/fx"
+	"io"
 	"log/slog"
 	"os"
 )
 
+type Params struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    Config
+}
+
+// New builds the logger described by the config, every line carries the request attributes of its context
+func New(p Params) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(p.Config.Level)); err != nil {
+		return nil, fmt.Errorf("log level: %w", err)
+	}
+
+	var output io.Writer
+	switch p.Config.Output {
+	case "", "stdout":
+		output = os.Stdout
+	case "stderr":
+		output = os.Stderr
+	default:
+		file, err := os.OpenFile(p.Config.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("log output: %w", err)
+		}
+		p.Lifecycle.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				return file.Close()
+			},
+		})
+		output = file
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	switch p.Config.Format {
+	case "", FormatText:
+		return slog.New(NewContextHandler(slog.NewTextHandler(output, options))), nil
+	case FormatJSON:
+		return slog.New(NewContextHandler(slog.NewJSONHandler(output, options))), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", p.Config.Format)
+	}
+}
+
+// SetupLogger is the development logger, text lines at debug level to stdout.
+// It is used where no config is loaded.
 func SetupLogger() *slog.Logger {
-	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	log := slog.New(NewContextHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
-	}))
+	})))
 	return log
 }
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestContextHandler(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(NewContextHandler(slog.NewJSONHandler(&buf, nil))).With("component", "repo")
+
+	ctx := WithAttrs(context.Background(), slog.String("request_id", "abc"))
+	ctx = WithAttrs(ctx, slog.String("route", "GET /api/v1/employees/{id}"))
+	log.InfoContext(ctx, "get employee", "id", 5)
+
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "abc", line["request_id"])
+	assert.Equal(t, "GET /api/v1/employees/{id}", line["route"])
+	assert.Equal(t, "repo", line["component"])
+	assert.Equal(t, float64(5), line["id"])
+
+	buf.Reset()
+	log.Info("without context")
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.NotContains(t, buf.String(), "request_id")
+}
+
+func TestWithAttrsDoesNotShareAttrs(t *testing.T) {
+	parent := WithAttrs(context.Background(), slog.String("request_id", "abc"), slog.String("route", "GET /"))
+	first := WithAttrs(parent, slog.Int("batch", 1))
+	second := WithAttrs(parent, slog.Int("batch", 2))
+
+	assert.Equal(t, int64(1), first.Value(attrsKey{}).([]slog.Attr)[2].Value.Int64())
+	assert.Equal(t, int64(2), second.Value(attrsKey{}).([]slog.Attr)[2].Value.Int64())
+}
+
+func TestNew(t *testing.T) {
+	testTable := []struct {
+		name      string
+		config    Config
+		expectErr bool
+	}{
+		{name: "json to stdout", config: Config{Level: "info", Format: FormatJSON, Output: "stdout"}},
+		{name: "text to a file", config: Config{Level: "warn", Format: FormatText, Output: filepath.Join(t.TempDir(), "employees.log")}},
+		{name: "unknown level", config: Config{Level: "verbose", Format: FormatText}, expectErr: true},
+		{name: "unknown format", config: Config{Level: "info", Format: "xml"}, expectErr: true},
+		{name: "missing directory", config: Config{Level: "info", Output: filepath.Join(t.TempDir(), "missing", "employees.log")}, expectErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			lifecycle := fxtest.NewLifecycle(t)
+			log, err := New(Params{Lifecycle: lifecycle, Config: testCase.config})
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, log)
+			lifecycle.RequireStart().RequireStop()
+		})
+	}
+}
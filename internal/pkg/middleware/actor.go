@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"employees/internal/pkg/audit"
+	"net/http"
+)
+
+// ActorMiddleware stores the X-Actor header in the request context so changes can be attributed in the audit log
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get("X-Actor"); actor != "" {
+			r = r.WithContext(audit.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
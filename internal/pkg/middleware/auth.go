@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"employees/internal/pkg/audit"
+	"employees/internal/pkg/auth"
+	"employees/internal/pkg/utils"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net/http"
+)
+
+// AuthMiddleware rejects requests without valid credentials with 401. The caller identity is stored
+// in the request context and replaces the X-Actor header as the audit actor.
+func AuthMiddleware(authenticator *auth.Authenticator, log *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, err := authenticator.Authenticate(r)
+			if err != nil {
+				log.WarnContext(r.Context(), "authenticate", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="employees"`)
+				utils.SendError(w, err)
+				return
+			}
+
+			log.DebugContext(r.Context(), "authenticated", "subject", identity.Subject, "auth_method", identity.Method, "role", identity.Role)
+			ctx := auth.WithIdentity(r.Context(), identity)
+			ctx = audit.WithActor(ctx, identity.Actor())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"employees/internal/pkg/logger"
+	"encoding/hex"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net/http"
+	"regexp"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits the ids accepted from the caller to what is safe to put into a log line
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware takes the X-Request-ID of the caller or generates one, echoes it in the response
+// and adds it with the matched route to every line logged with the request context
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		attrs := []slog.Attr{slog.String("request_id", requestID)}
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				attrs = append(attrs, slog.String("route", r.Method+" "+template))
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(logger.WithAttrs(r.Context(), attrs...)))
+	})
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"employees/internal/pkg/logger"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	testTable := []struct {
+		name      string
+		requestID string
+		keep      bool
+	}{
+		{name: "accepted", requestID: "0f8fad5b-d9cb-469f-a165-70867728950e", keep: true},
+		{name: "generated", requestID: ""},
+		{name: "replaced when unsafe", requestID: "abc\nlevel=ERROR"},
+		{name: "replaced when too long", requestID: strings.Repeat("a", 129)},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			var buf strings.Builder
+			log := slog.New(logger.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+			router := mux.NewRouter()
+			router.Use(RequestIDMiddleware)
+			router.HandleFunc("/employees/{id}", func(w http.ResponseWriter, r *http.Request) {
+				log.InfoContext(r.Context(), "get employee")
+			}).Methods(http.MethodGet)
+
+			req := httptest.NewRequest(http.MethodGet, "/employees/5", nil)
+			if testCase.requestID != "" {
+				req.Header.Set(RequestIDHeader, testCase.requestID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(RequestIDHeader)
+			if testCase.keep {
+				assert.Equal(t, testCase.requestID, requestID)
+			} else {
+				assert.Len(t, requestID, 32)
+			}
+
+			var line map[string]any
+			assert.NoError(t, json.Unmarshal([]byte(buf.String()), &line))
+			assert.Equal(t, requestID, line["request_id"])
+			assert.Equal(t, "GET /employees/{id}", line["route"])
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package orgchart
+
+import (
+	"bufio"
+	"strconv"
+)
+
+// dotGraph renders Graphviz DOT
+type dotGraph struct{}
+
+var dotShapes = map[nodeKind]string{
+	companyNode:    "box3d",
+	departmentNode: "box",
+	employeeNode:   "ellipse",
+}
+
+func (dotGraph) begin(w *bufio.Writer) {
+	_, _ = w.WriteString("digraph orgchart {\n")
+	_, _ = w.WriteString("    rankdir=TB;\n")
+}
+
+func (dotGraph) node(w *bufio.Writer, id, label string, kind nodeKind) {
+	// strconv.Quote escapes quotes and backslashes the same way DOT strings expect them
+	_, _ = w.WriteString("    " + id + " [label=" + strconv.Quote(label) + ", shape=" + dotShapes[kind] + "];\n")
+}
+
+func (dotGraph) edge(w *bufio.Writer, from, to string) {
+	_, _ = w.WriteString("    " + from + " -> " + to + ";\n")
+}
+
+func (dotGraph) end(w *bufio.Writer) {
+	_, _ = w.WriteString("}\n")
+}
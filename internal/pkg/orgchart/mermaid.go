@@ -0,0 +1,34 @@
+package orgchart
+
+import (
+	"bufio"
+	"strings"
+)
+
+// mermaidGraph renders a Mermaid flowchart
+type mermaidGraph struct{}
+
+// mermaidShapes holds the opening and closing brackets of each node shape
+var mermaidShapes = map[nodeKind][2]string{
+	companyNode:    {"[[", "]]"},
+	departmentNode: {"[", "]"},
+	employeeNode:   {"(", ")"},
+}
+
+// mermaidEscaper replaces the characters that end a quoted label with Mermaid entity codes
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "\n", " ")
+
+func (mermaidGraph) begin(w *bufio.Writer) {
+	_, _ = w.WriteString("flowchart TD\n")
+}
+
+func (mermaidGraph) node(w *bufio.Writer, id, label string, kind nodeKind) {
+	shape := mermaidShapes[kind]
+	_, _ = w.WriteString("    " + id + shape[0] + `"` + mermaidEscaper.Replace(label) + `"` + shape[1] + "\n")
+}
+
+func (mermaidGraph) edge(w *bufio.Writer, from, to string) {
+	_, _ = w.WriteString("    " + from + " --> " + to + "\n")
+}
+
+func (mermaidGraph) end(*bufio.Writer) {}
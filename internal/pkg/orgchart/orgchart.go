@@ -0,0 +1,91 @@
+package orgchart
+
+import (
+	"bufio"
+	"employees/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+var ErrUnknownFormat = errors.New("unknown org chart format")
+
+// Render writes the chart in the given textual format
+func Render(w io.Writer, format string, chart *models.OrgChart) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return enc.Encode(chart)
+	case FormatDOT:
+		return render(w, chart, dotGraph{})
+	case FormatMermaid:
+		return render(w, chart, mermaidGraph{})
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatJSON:
+		return "application/json"
+	case FormatDOT:
+		return "text/vnd.graphviz; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// graph is a text syntax for a directed graph of labelled nodes
+type graph interface {
+	begin(w *bufio.Writer)
+	node(w *bufio.Writer, id, label string, kind nodeKind)
+	edge(w *bufio.Writer, from, to string)
+	end(w *bufio.Writer)
+}
+
+type nodeKind int
+
+const (
+	companyNode nodeKind = iota
+	departmentNode
+	employeeNode
+)
+
+// render walks the chart top down, every node is declared before the edge leading to it
+func render(w io.Writer, chart *models.OrgChart, g graph) error {
+	bw := bufio.NewWriter(w)
+	g.begin(bw)
+
+	company := fmt.Sprintf("company_%d", chart.ID)
+	g.node(bw, company, chart.Name, companyNode)
+	for _, department := range chart.Departments {
+		renderDepartment(bw, g, company, department)
+	}
+
+	g.end(bw)
+	return bw.Flush()
+}
+
+func renderDepartment(w *bufio.Writer, g graph, parent string, department *models.OrgDepartment) {
+	id := fmt.Sprintf("department_%d", department.ID)
+	g.node(w, id, department.Name, departmentNode)
+	g.edge(w, parent, id)
+
+	for _, employee := range department.Employees {
+		employeeID := fmt.Sprintf("employee_%d", employee.ID)
+		g.node(w, employeeID, employee.Name+" "+employee.Surname, employeeNode)
+		g.edge(w, id, employeeID)
+	}
+	for _, child := range department.Departments {
+		renderDepartment(w, g, id, child)
+	}
+}
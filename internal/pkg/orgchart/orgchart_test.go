@@ -0,0 +1,67 @@
+package orgchart
+
+import (
+	"bytes"
+	"employees/internal/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var chart = &models.OrgChart{
+	ID:   1,
+	Name: `ООО "Ромашка"`,
+	Departments: []*models.OrgDepartment{
+		{
+			ID:        2,
+			Name:      "dev",
+			Phone:     "1234",
+			Employees: []*models.OrgEmployee{{ID: 5, Name: "katya", Surname: "ivanova", Phone: "+79161234567"}},
+			Departments: []*models.OrgDepartment{
+				{ID: 3, Name: "backend", Phone: "5678", Employees: []*models.OrgEmployee{}, Departments: []*models.OrgDepartment{}},
+			},
+		},
+	},
+}
+
+func renderChart(t *testing.T, format string) string {
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, format, chart))
+	return buf.String()
+}
+
+func TestRenderJSON(t *testing.T) {
+	assert.JSONEq(t, `{"id":1,"name":"ООО \"Ромашка\"","departments":[
+{"id":2,"name":"dev","phone":"1234","employees":[{"id":5,"name":"katya","surname":"ivanova","phone":"+79161234567"}],
+"departments":[{"id":3,"name":"backend","phone":"5678","employees":[],"departments":[]}]}
+]}`, renderChart(t, FormatJSON))
+}
+
+func TestRenderDOT(t *testing.T) {
+	assert.Equal(t, `digraph orgchart {
+    rankdir=TB;
+    company_1 [label="ООО \"Ромашка\"", shape=box3d];
+    department_2 [label="dev", shape=box];
+    company_1 -> department_2;
+    employee_5 [label="katya ivanova", shape=ellipse];
+    department_2 -> employee_5;
+    department_3 [label="backend", shape=box];
+    department_2 -> department_3;
+}
+`, renderChart(t, FormatDOT))
+}
+
+func TestRenderMermaid(t *testing.T) {
+	assert.Equal(t, `flowchart TD
+    company_1[["ООО #quot;Ромашка#quot;"]]
+    department_2["dev"]
+    company_1 --> department_2
+    employee_5("katya ivanova")
+    department_2 --> employee_5
+    department_3["backend"]
+    department_2 --> department_3
+`, renderChart(t, FormatMermaid))
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	assert.ErrorIs(t, Render(&bytes.Buffer{}, "svg", chart), ErrUnknownFormat)
+}
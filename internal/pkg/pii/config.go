@@ -0,0 +1,16 @@
+package pii
+
+type Config struct {
+	// ActiveKey is the id of the key new values are sealed with, the other keys only open older values
+	ActiveKey string      `yaml:"activeKey" env:"PII_ACTIVE_KEY"`
+	Keys      []KeyConfig `yaml:"keys"`
+	// IndexKeyEnv names the variable holding the blind index key. The index is what uniqueness
+	// and lookups compare, so this key can not be rotated without rebuilding it.
+	IndexKeyEnv string `yaml:"indexKeyEnv"`
+}
+
+// KeyConfig is one key encryption key, SecretEnv names the variable holding 32 base64 encoded bytes
+type KeyConfig struct {
+	ID        string `yaml:"id"`
+	SecretEnv string `yaml:"secretEnv"`
+}
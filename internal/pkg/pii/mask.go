@@ -0,0 +1,14 @@
+package pii
+
+// visibleRunes is how many trailing characters Mask keeps
+const visibleRunes = 4
+
+// Mask hides all but the last characters of the value, 4510 123456 becomes ****3456.
+// The audit log migration masks stored snapshots the same way.
+func Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	runes := []rune(value)
+	return "****" + string(runes[max(0, len(runes)-visibleRunes):])
+}
@@ -0,0 +1,171 @@
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go.uber.org/fx"
+	"os"
+)
+
+// keySize is the size of the key encryption keys and the data keys, AES-256
+const keySize = 32
+
+// wrappedKeySize is the size of a data key sealed with a key encryption key: nonce, key and tag
+const wrappedKeySize = 12 + keySize + 16
+
+type Params struct {
+	fx.In
+
+	Config Config
+}
+
+// Vault encrypts personal data with envelope encryption: every value gets a data key of its own,
+// the data key is sealed with the active key encryption key and stored next to the value
+type Vault struct {
+	active   string
+	keys     map[string]cipher.AEAD
+	indexKey []byte
+}
+
+// Sealed is an encrypted value. Ciphertext holds the wrapped data key followed by the value
+// sealed with it, KeyID names the key encryption key that wraps the data key.
+type Sealed struct {
+	KeyID      string
+	Ciphertext []byte
+}
+
+func New(p Params) (*Vault, error) {
+	if p.Config.ActiveKey == "" {
+		return nil, errors.New("active pii key is not set")
+	}
+	v := &Vault{
+		active: p.Config.ActiveKey,
+		keys:   make(map[string]cipher.AEAD, len(p.Config.Keys)),
+	}
+	for _, keyConfig := range p.Config.Keys {
+		secret, err := readSecret(keyConfig.SecretEnv)
+		if err != nil {
+			return nil, fmt.Errorf("pii key %q: %w", keyConfig.ID, err)
+		}
+		if v.keys[keyConfig.ID], err = newAEAD(secret); err != nil {
+			return nil, fmt.Errorf("pii key %q: %w", keyConfig.ID, err)
+		}
+	}
+	if _, ok := v.keys[v.active]; !ok {
+		return nil, fmt.Errorf("active pii key %q is not configured", v.active)
+	}
+
+	var err error
+	if v.indexKey, err = readSecret(p.Config.IndexKeyEnv); err != nil {
+		return nil, fmt.Errorf("pii index key: %w", err)
+	}
+	return v, nil
+}
+
+// ActiveKeyID is the key new values are sealed with
+func (v *Vault) ActiveKeyID() string {
+	return v.active
+}
+
+// Seal encrypts the value under a new data key wrapped with the active key
+func (v *Vault) Seal(plaintext string) (Sealed, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return Sealed{}, err
+	}
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return Sealed{}, err
+	}
+
+	// the key id is authenticated with the wrapped key, so a value can not be moved to another key
+	wrapped, err := seal(v.keys[v.active], dataKey, []byte(v.active))
+	if err != nil {
+		return Sealed{}, err
+	}
+	ciphertext, err := seal(data, []byte(plaintext), wrapped)
+	if err != nil {
+		return Sealed{}, err
+	}
+	return Sealed{KeyID: v.active, Ciphertext: append(wrapped, ciphertext...)}, nil
+}
+
+// Open decrypts a value sealed with any of the configured keys
+func (v *Vault) Open(sealed Sealed) (string, error) {
+	kek, ok := v.keys[sealed.KeyID]
+	if !ok {
+		return "", fmt.Errorf("pii key %q is not configured", sealed.KeyID)
+	}
+	if len(sealed.Ciphertext) < wrappedKeySize {
+		return "", errors.New("sealed value is too short")
+	}
+
+	wrapped := sealed.Ciphertext[:wrappedKeySize]
+	dataKey, err := open(kek, wrapped, []byte(sealed.KeyID))
+	if err != nil {
+		return "", fmt.Errorf("unwrap data key: %w", err)
+	}
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(data, sealed.Ciphertext[wrappedKeySize:], wrapped)
+	if err != nil {
+		return "", fmt.Errorf("open value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex is a keyed hash of the value, equal values get equal indexes
+// so that uniqueness and lookups work without decrypting
+func (v *Vault) BlindIndex(value string) []byte {
+	mac := hmac.New(sha256.New, v.indexKey)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+func readSecret(env string) ([]byte, error) {
+	value := os.Getenv(env)
+	if value == "" {
+		return nil, fmt.Errorf("%s is not set", env)
+	}
+	secret, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not base64: %w", env, err)
+	}
+	if len(secret) != keySize {
+		return nil, fmt.Errorf("%s must hold %d bytes", env, keySize)
+	}
+	return secret, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal prepends a random nonce to the sealed plaintext
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
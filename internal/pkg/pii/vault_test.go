@@ -0,0 +1,109 @@
+package pii
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVault(t *testing.T, active string) *Vault {
+	t.Helper()
+	t.Setenv("TEST_PII_OLD", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", keySize))))
+	t.Setenv("TEST_PII_NEW", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("n", keySize))))
+	t.Setenv("TEST_PII_INDEX", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("i", keySize))))
+
+	v, err := New(Params{Config: Config{
+		ActiveKey:   active,
+		Keys:        []KeyConfig{{ID: "old", SecretEnv: "TEST_PII_OLD"}, {ID: "new", SecretEnv: "TEST_PII_NEW"}},
+		IndexKeyEnv: "TEST_PII_INDEX",
+	}})
+	assert.NoError(t, err)
+	return v
+}
+
+func TestVault_SealOpen(t *testing.T) {
+	v := newTestVault(t, "new")
+
+	sealed, err := v.Seal("4510 123456")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", sealed.KeyID)
+	assert.NotContains(t, string(sealed.Ciphertext), "4510 123456")
+
+	opened, err := v.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "4510 123456", opened)
+
+	again, err := v.Seal("4510 123456")
+	assert.NoError(t, err)
+	assert.NotEqual(t, sealed.Ciphertext, again.Ciphertext)
+}
+
+func TestVault_OpenOlderKey(t *testing.T) {
+	sealed, err := newTestVault(t, "old").Seal("4510 123456")
+	assert.NoError(t, err)
+
+	opened, err := newTestVault(t, "new").Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "4510 123456", opened)
+}
+
+func TestVault_OpenFails(t *testing.T) {
+	v := newTestVault(t, "new")
+	sealed, err := v.Seal("4510 123456")
+	assert.NoError(t, err)
+
+	tampered := Sealed{KeyID: sealed.KeyID, Ciphertext: append([]byte(nil), sealed.Ciphertext...)}
+	tampered.Ciphertext[len(tampered.Ciphertext)-1] ^= 1
+
+	testTable := []struct {
+		name   string
+		sealed Sealed
+	}{
+		{name: "unknown key", sealed: Sealed{KeyID: "lost", Ciphertext: sealed.Ciphertext}},
+		{name: "moved to another key", sealed: Sealed{KeyID: "old", Ciphertext: sealed.Ciphertext}},
+		{name: "tampered", sealed: tampered},
+		{name: "truncated", sealed: Sealed{KeyID: sealed.KeyID, Ciphertext: sealed.Ciphertext[:wrappedKeySize]}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := v.Open(testCase.sealed)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVault_BlindIndex(t *testing.T) {
+	v := newTestVault(t, "new")
+	assert.Equal(t, v.BlindIndex("4510 123456"), newTestVault(t, "old").BlindIndex("4510 123456"))
+	assert.NotEqual(t, v.BlindIndex("4510 123456"), v.BlindIndex("4510 123457"))
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("TEST_PII_SHORT", base64.StdEncoding.EncodeToString([]byte("short")))
+	t.Setenv("TEST_PII_KEY", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", keySize))))
+
+	_, err := New(Params{Config: Config{ActiveKey: "k", Keys: []KeyConfig{{ID: "k", SecretEnv: "TEST_PII_SHORT"}}, IndexKeyEnv: "TEST_PII_KEY"}})
+	assert.Error(t, err)
+
+	_, err = New(Params{Config: Config{ActiveKey: "other", Keys: []KeyConfig{{ID: "k", SecretEnv: "TEST_PII_KEY"}}, IndexKeyEnv: "TEST_PII_KEY"}})
+	assert.Error(t, err)
+
+	_, err = New(Params{Config: Config{ActiveKey: "k", Keys: []KeyConfig{{ID: "k", SecretEnv: "TEST_PII_KEY"}}, IndexKeyEnv: "TEST_PII_MISSING"}})
+	assert.Error(t, err)
+
+	_, err = New(Params{Config: Config{ActiveKey: "k", Keys: []KeyConfig{{ID: "k", SecretEnv: "TEST_PII_MISSING"}}, IndexKeyEnv: "TEST_PII_KEY"}})
+	assert.ErrorContains(t, err, "TEST_PII_MISSING is not set")
+
+	_, err = New(Params{Config: Config{Keys: []KeyConfig{{ID: "k", SecretEnv: "TEST_PII_KEY"}}, IndexKeyEnv: "TEST_PII_KEY"}})
+	assert.ErrorContains(t, err, "active pii key is not set")
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "****3456", Mask("4510 123456"))
+	assert.Equal(t, "****12", Mask("12"))
+	assert.Equal(t, "****ЙЦУК", Mask("АБВЙЦУК"))
+	assert.Equal(t, "", Mask(""))
+}
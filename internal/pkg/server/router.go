@@ -2,6 +2,7 @@ package server
 
 import (
 	_ "employees/docs"
+	"employees/internal/pkg/auth"
 	handlerEmployee "employees/internal/pkg/employee/delivery/http"
 	"employees/internal/pkg/middleware"
 	"github.com/gorilla/mux"
@@ -15,6 +16,7 @@ type RouterParams struct {
 	fx.In
 
 	Handler *handlerEmployee.Handler
+	Auth    *auth.Authenticator
 	Logger  *slog.Logger
 }
 
@@ -24,26 +26,65 @@ type Router struct {
 
 func NewRouter(p RouterParams) *Router {
 	api := mux.NewRouter().PathPrefix("/api").Subrouter()
+	api.Use(middleware.RequestIDMiddleware)
 	api.Use(middleware.CORSMiddleware)
+	api.Use(middleware.ActorMiddleware)
 
 	v1 := api.PathPrefix("/v1").Subrouter()
 	v1.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
-	employees := v1.PathPrefix("/employees").Subrouter()
+	// everything except the swagger docs requires credentials
+	protected := v1.NewRoute().Subrouter()
+	if p.Auth.Enabled() {
+		protected.Use(middleware.AuthMiddleware(p.Auth, p.Logger))
+	} else {
+		p.Logger.Warn("authentication is disabled")
+	}
+
+	// registered before the employees subrouter, which would otherwise take the path by prefix
+	protected.HandleFunc("/employees:batch", p.Handler.BatchEmployees).Methods(http.MethodPost)
+
+	employees := protected.PathPrefix("/employees").Subrouter()
 
 	employees.HandleFunc("", p.Handler.CreateEmployee).Methods(http.MethodPost)
+	employees.HandleFunc("/search", p.Handler.SearchEmployees).Methods(http.MethodGet)
+	employees.HandleFunc("/archived", p.Handler.GetArchivedEmployees).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}", p.Handler.GetEmployee).Methods(http.MethodGet)
 	employees.HandleFunc("/{id}", p.Handler.DeleteEmployee).Methods(http.MethodDelete)
 	employees.HandleFunc("/{id}", p.Handler.UpdateEmployee).Methods(http.MethodPatch)
+	employees.HandleFunc("/{id}/restore", p.Handler.RestoreEmployee).Methods(http.MethodPost)
+	employees.HandleFunc("/{id}/history", p.Handler.GetEmployeeHistory).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}/transfer", p.Handler.TransferEmployee).Methods(http.MethodPost)
+	employees.HandleFunc("/{id}/assignments", p.Handler.GetEmployeeAssignments).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}/reporting-chain", p.Handler.GetReportingChain).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}/direct-reports", p.Handler.GetDirectReports).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}/documents", p.Handler.GetEmployeeDocuments).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}/documents", p.Handler.CreateEmployeeDocument).Methods(http.MethodPost)
+	employees.HandleFunc("/{id}/documents/{documentId}", p.Handler.GetEmployeeDocument).Methods(http.MethodGet)
+	employees.HandleFunc("/{id}/documents/{documentId}", p.Handler.UpdateEmployeeDocument).Methods(http.MethodPatch)
+	employees.HandleFunc("/{id}/documents/{documentId}", p.Handler.DeleteEmployeeDocument).Methods(http.MethodDelete)
 
-	companies := v1.PathPrefix("/companies").Subrouter()
+	companies := protected.PathPrefix("/companies").Subrouter()
 
 	companies.HandleFunc("/{id}/employees", p.Handler.GetCompanyEmployees).Methods(http.MethodGet)
+	companies.HandleFunc("/{id}/employees:import", p.Handler.ImportEmployees).Methods(http.MethodPost)
+	companies.HandleFunc("/{id}/employees/export", p.Handler.ExportEmployees).Methods(http.MethodGet)
+	companies.HandleFunc("/{id}/departments", p.Handler.GetCompanyDepartments).Methods(http.MethodGet)
+	companies.HandleFunc("/{id}/orgchart", p.Handler.GetOrgChart).Methods(http.MethodGet)
 	companies.HandleFunc("", p.Handler.CreateCompany).Methods(http.MethodPost)
+	companies.HandleFunc("", p.Handler.GetCompanies).Methods(http.MethodGet)
+	companies.HandleFunc("/{id}", p.Handler.GetCompany).Methods(http.MethodGet)
+	companies.HandleFunc("/{id}", p.Handler.UpdateCompany).Methods(http.MethodPatch)
+	companies.HandleFunc("/{id}", p.Handler.DeleteCompany).Methods(http.MethodDelete)
 
-	departments := v1.PathPrefix("/departments").Subrouter()
+	departments := protected.PathPrefix("/departments").Subrouter()
 
 	departments.HandleFunc("/{id}/employees", p.Handler.GetDepartmentCompanyEmployees).Methods(http.MethodGet)
 	departments.HandleFunc("", p.Handler.CreateDepartment).Methods(http.MethodPost)
+	departments.HandleFunc("/{id}", p.Handler.GetDepartment).Methods(http.MethodGet)
+	departments.HandleFunc("/{id}/subtree", p.Handler.GetDepartmentSubtree).Methods(http.MethodGet)
+	departments.HandleFunc("/{id}", p.Handler.UpdateDepartment).Methods(http.MethodPatch)
+	departments.HandleFunc("/{id}", p.Handler.DeleteDepartment).Methods(http.MethodDelete)
 
 	router := &Router{
 		handler: api,
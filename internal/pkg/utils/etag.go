@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"employees/internal/pkg/errs"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FormatETag turns a record version into a strong entity tag.
+func FormatETag(version int32) string {
+	return `"` + strconv.Itoa(int(version)) + `"`
+}
+
+// ReadIfMatch returns the version expected by the If-Match header.
+// ok is false when there is no header; a nil version stands for "*", which matches any existing record.
+// Tags this service never issues, including weak ones, can not match and give errs.ErrPreconditionFailed.
+func ReadIfMatch(r *http.Request) (version *int32, ok bool, err error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return nil, false, nil
+	}
+	if header == "*" {
+		return nil, true, nil
+	}
+
+	tag, found := strings.CutPrefix(header, `"`)
+	tag, closed := strings.CutSuffix(tag, `"`)
+	if !found || !closed {
+		return nil, true, errs.ErrPreconditionFailed
+	}
+	v, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil {
+		return nil, true, errs.ErrPreconditionFailed
+	}
+	parsed := int32(v)
+	return &parsed, true, nil
+}
@@ -1,7 +1,32 @@
 package messages
 
 var (
-	BadRequest          = "Bad Request"
-	InternalServerError = "Internal Server Error"
-	NotFound            = "Not Found"
+	BadRequest           = "Bad Request"
+	InternalServerError  = "Internal Server Error"
+	NotFound             = "Not Found"
+	Unauthorized         = "Unauthorized"
+	Forbidden            = "Forbidden"
+	UnsupportedMediaType = "Unsupported Media Type"
+	Conflict             = "Conflict"
+	InvalidReference     = "Invalid Reference"
+	DepartmentMismatch   = "Department Belongs To Another Company"
+	HierarchyCycle       = "Hierarchy Would Contain A Cycle"
+	ValidationFailed     = "Validation Failed"
+	PreconditionFailed   = "Precondition Failed"
+	PreconditionRequired = "Precondition Required"
+)
+
+// machine-readable error codes returned alongside the message
+var (
+	CodeNotFound             = "not_found"
+	CodeUnauthenticated      = "unauthenticated"
+	CodeForbidden            = "forbidden"
+	CodeConflict             = "conflict"
+	CodeInvalidReference     = "invalid_reference"
+	CodeDepartmentMismatch   = "department_company_mismatch"
+	CodeHierarchyCycle       = "hierarchy_cycle"
+	CodeValidation           = "validation_failed"
+	CodePreconditionFailed   = "precondition_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeInternal             = "internal_error"
 )
@@ -1,11 +1,29 @@
 package utils
 
 import (
+	"employees/internal/models"
 	"encoding/json"
+	"errors"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
 )
 
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+const (
+	ContentTypeJSON       = "application/json"
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeCSV        = "text/csv"
+	ContentTypeNDJSON     = "application/x-ndjson"
+)
+
+var ErrInvalidPagination = errors.New("invalid pagination parameters")
+
 func ReadRequestData(r *http.Request, request interface{}) error {
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -17,3 +35,48 @@ func ReadRequestData(r *http.Request, request interface{}) error {
 	}
 	return nil
 }
+
+func ReadPagination(r *http.Request) (*models.Pagination, error) {
+	pagination := &models.Pagination{
+		Limit:  DefaultLimit,
+		Offset: 0,
+	}
+
+	query := r.URL.Query()
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > MaxLimit {
+			return nil, ErrInvalidPagination
+		}
+		pagination.Limit = int32(limit)
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return nil, ErrInvalidPagination
+		}
+		pagination.Offset = int32(offset)
+	}
+
+	return pagination, nil
+}
+
+// HasContentType reports whether the request body has one of the allowed media types.
+// A request without Content-Type is treated as JSON.
+func HasContentType(r *http.Request, allowed ...string) bool {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range allowed {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"employees/internal/pkg/errs"
+	"employees/internal/pkg/utils/messages"
 	"encoding/json"
+	"errors"
 	"net/http"
 )
 
@@ -9,6 +12,13 @@ type MessageResponse struct {
 	Msg string `json:"msg"`
 }
 
+type ErrorResponse struct {
+	Code   string            `json:"code"`
+	Msg    string            `json:"msg"`
+	Fields []errs.FieldError `json:"fields,omitempty"`
+	Reason string            `json:"reason,omitempty"`
+}
+
 func Send200(w http.ResponseWriter, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
@@ -29,6 +39,16 @@ func Send201(w http.ResponseWriter, v interface{}) {
 	_, _ = w.Write(resp)
 }
 
+func SendJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	resp, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_, _ = w.Write(resp)
+}
+
 func Send500(w http.ResponseWriter, msg string) {
 	resp, err := json.Marshal(MessageResponse{msg})
 	if err != nil {
@@ -55,3 +75,61 @@ func Send404(w http.ResponseWriter, msg string) {
 	w.WriteHeader(404)
 	_, _ = w.Write(resp)
 }
+
+func Send415(w http.ResponseWriter, msg string) {
+	resp, err := json.Marshal(MessageResponse{msg})
+	if err != nil {
+		return
+	}
+	w.WriteHeader(415)
+	_, _ = w.Write(resp)
+}
+
+// SendError writes a domain error from the errs package with the matching status code.
+// Unknown errors are reported as 500 without exposing details.
+func SendError(w http.ResponseWriter, err error) {
+	status, resp := ErrorStatus(err)
+	body, marshalErr := json.Marshal(resp)
+	if marshalErr != nil {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// ErrorStatus maps a domain error to the status code and body SendError writes
+func ErrorStatus(err error) (int, ErrorResponse) {
+	status, resp := http.StatusInternalServerError, ErrorResponse{Code: messages.CodeInternal, Msg: messages.InternalServerError}
+	switch {
+	case errors.Is(err, errs.ErrUnauthenticated):
+		status, resp = http.StatusUnauthorized, ErrorResponse{Code: messages.CodeUnauthenticated, Msg: messages.Unauthorized}
+	case errors.Is(err, errs.ErrForbidden):
+		status, resp = http.StatusForbidden, ErrorResponse{Code: messages.CodeForbidden, Msg: messages.Forbidden}
+		var forbiddenErr *errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			resp.Reason = forbiddenErr.Reason
+		}
+	case errors.Is(err, errs.ErrNotFound):
+		status, resp = http.StatusNotFound, ErrorResponse{Code: messages.CodeNotFound, Msg: messages.NotFound}
+	case errors.Is(err, errs.ErrConflict):
+		status, resp = http.StatusConflict, ErrorResponse{Code: messages.CodeConflict, Msg: messages.Conflict}
+	case errors.Is(err, errs.ErrDepartmentCompanyMismatch):
+		status, resp = http.StatusUnprocessableEntity, ErrorResponse{Code: messages.CodeDepartmentMismatch, Msg: messages.DepartmentMismatch}
+	case errors.Is(err, errs.ErrHierarchyCycle):
+		status, resp = http.StatusUnprocessableEntity, ErrorResponse{Code: messages.CodeHierarchyCycle, Msg: messages.HierarchyCycle}
+	case errors.Is(err, errs.ErrInvalidReference):
+		status, resp = http.StatusUnprocessableEntity, ErrorResponse{Code: messages.CodeInvalidReference, Msg: messages.InvalidReference}
+	case errors.Is(err, errs.ErrValidation):
+		status, resp = http.StatusUnprocessableEntity, ErrorResponse{Code: messages.CodeValidation, Msg: messages.ValidationFailed}
+		var validationErr *errs.ValidationError
+		if errors.As(err, &validationErr) {
+			resp.Fields = validationErr.Fields
+		}
+	case errors.Is(err, errs.ErrPreconditionFailed):
+		status, resp = http.StatusPreconditionFailed, ErrorResponse{Code: messages.CodePreconditionFailed, Msg: messages.PreconditionFailed}
+	case errors.Is(err, errs.ErrPreconditionRequired):
+		status, resp = http.StatusPreconditionRequired, ErrorResponse{Code: messages.CodePreconditionRequired, Msg: messages.PreconditionRequired}
+	}
+	return status, resp
+}
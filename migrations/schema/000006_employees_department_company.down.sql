@@ -0,0 +1,3 @@
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_department_company_fkey;
+
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS departments_id_company_id_key;
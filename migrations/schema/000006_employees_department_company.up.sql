@@ -0,0 +1,34 @@
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS departments_id_company_id_key;
+ALTER TABLE departments
+    ADD CONSTRAINT departments_id_company_id_key UNIQUE (id, company_id);
+
+-- new rows are checked at once, existing ones only after the repair below
+ALTER TABLE employees
+    ADD CONSTRAINT employees_department_company_fkey
+        FOREIGN KEY (department_id, company_id) REFERENCES departments (id, company_id) NOT VALID;
+
+-- employees whose department belongs to another company move to the company of the department
+DO
+$$
+    DECLARE
+        moved INTEGER[];
+    BEGIN
+        WITH repaired AS (
+            UPDATE employees e
+                SET company_id = d.company_id,
+                    updated_at = now()
+                FROM departments d
+                WHERE d.id = e.department_id
+                    AND d.company_id <> e.company_id
+                RETURNING e.id)
+        SELECT array_agg(id ORDER BY id)
+        INTO moved
+        FROM repaired;
+
+        IF moved IS NOT NULL THEN
+            RAISE WARNING 'employees % moved to the company of their department', moved;
+        END IF;
+    END
+$$;
+
+ALTER TABLE employees VALIDATE CONSTRAINT employees_department_company_fkey;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS employees_company_name_idx;
+DROP INDEX IF EXISTS employees_company_surname_idx;
+DROP INDEX IF EXISTS employees_company_created_at_idx;
+DROP INDEX IF EXISTS employees_department_id_idx;
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS employees_company_name_idx ON employees (company_id, name, id);
+CREATE INDEX IF NOT EXISTS employees_company_surname_idx ON employees (company_id, surname, id);
+CREATE INDEX IF NOT EXISTS employees_company_created_at_idx ON employees (company_id, created_at, id);
+CREATE INDEX IF NOT EXISTS employees_department_id_idx ON employees (department_id, id);
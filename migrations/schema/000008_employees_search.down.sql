@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS employees_phone_trgm_idx;
+DROP INDEX IF EXISTS employees_surname_trgm_idx;
+DROP INDEX IF EXISTS employees_name_trgm_idx;
+DROP INDEX IF EXISTS employees_search_fts_idx;
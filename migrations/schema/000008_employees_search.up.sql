@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS employees_search_fts_idx ON employees
+    USING gin (to_tsvector('simple', name || ' ' || surname || ' ' || phone));
+
+CREATE INDEX IF NOT EXISTS employees_name_trgm_idx ON employees USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS employees_surname_trgm_idx ON employees USING gin (surname gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS employees_phone_trgm_idx ON employees USING gin (phone gin_trgm_ops);
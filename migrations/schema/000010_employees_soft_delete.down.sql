@@ -0,0 +1,9 @@
+DELETE FROM employees WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS employees_deleted_at_idx;
+DROP INDEX IF EXISTS employees_phone_key;
+DROP INDEX IF EXISTS employees_passport_number_key;
+ALTER TABLE employees ADD CONSTRAINT employees_phone_key UNIQUE (phone);
+ALTER TABLE employees ADD CONSTRAINT employees_passport_number_key UNIQUE (passport_number);
+
+ALTER TABLE employees DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- archived employees must not block reusing their phone and passport
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_phone_key;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_passport_number_key;
+CREATE UNIQUE INDEX IF NOT EXISTS employees_phone_key ON employees (phone) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS employees_passport_number_key ON employees (passport_number) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS employees_deleted_at_idx ON employees (deleted_at) WHERE deleted_at IS NOT NULL;
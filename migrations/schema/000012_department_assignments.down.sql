@@ -0,0 +1 @@
+DROP TABLE IF EXISTS department_assignments;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS department_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    from_department_id INTEGER REFERENCES departments (id) ON DELETE SET NULL,
+    department_id INTEGER NOT NULL REFERENCES departments (id) ON DELETE CASCADE,
+    effective_date DATE NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('scheduled', 'applied', 'cancelled')),
+    reason TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    applied_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS department_assignments_employee_idx ON department_assignments (employee_id, effective_date, id);
+CREATE INDEX IF NOT EXISTS department_assignments_due_idx ON department_assignments (effective_date, id) WHERE status = 'scheduled';
+-- one scheduled transfer per employee and day
+CREATE UNIQUE INDEX IF NOT EXISTS department_assignments_scheduled_key ON department_assignments (employee_id, effective_date) WHERE status = 'scheduled';
+
+-- the timeline of existing employees starts with their current department
+INSERT INTO department_assignments (employee_id, department_id, effective_date, status, actor, created_at, applied_at)
+SELECT id, department_id, created_at::date, 'applied', 'migration', created_at, created_at
+FROM employees;
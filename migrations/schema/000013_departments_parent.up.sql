@@ -0,0 +1,4 @@
+ALTER TABLE departments ADD COLUMN IF NOT EXISTS parent_department_id INTEGER REFERENCES departments (id) ON DELETE SET NULL;
+ALTER TABLE departments ADD CONSTRAINT departments_parent_not_self CHECK (parent_department_id <> id);
+
+CREATE INDEX IF NOT EXISTS departments_parent_department_id_idx ON departments (parent_department_id);
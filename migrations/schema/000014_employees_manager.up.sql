@@ -0,0 +1,4 @@
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS manager_id INTEGER REFERENCES employees (id) ON DELETE SET NULL;
+ALTER TABLE employees ADD CONSTRAINT employees_manager_not_self CHECK (manager_id <> id);
+
+CREATE INDEX IF NOT EXISTS employees_manager_id_idx ON employees (manager_id) WHERE deleted_at IS NULL;
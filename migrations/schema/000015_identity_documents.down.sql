@@ -0,0 +1,15 @@
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS passport_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS passport_number TEXT NOT NULL DEFAULT '';
+
+UPDATE employees e
+SET passport_type=d.type,
+    passport_number=d.number
+FROM identity_documents d
+WHERE d.employee_id = e.id
+  AND d.is_primary;
+
+ALTER TABLE employees ALTER COLUMN passport_type DROP DEFAULT;
+ALTER TABLE employees ALTER COLUMN passport_number DROP DEFAULT;
+CREATE UNIQUE INDEX IF NOT EXISTS employees_passport_number_key ON employees (passport_number) WHERE deleted_at IS NULL;
+
+DROP TABLE IF EXISTS identity_documents;
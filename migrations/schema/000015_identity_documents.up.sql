@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS identity_documents (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    number TEXT NOT NULL,
+    issuer TEXT NOT NULL DEFAULT '',
+    issue_date DATE,
+    expiry_date DATE,
+    country TEXT NOT NULL DEFAULT '',
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    archived BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT identity_documents_dates_check CHECK (expiry_date >= issue_date)
+);
+
+CREATE INDEX IF NOT EXISTS identity_documents_employee_idx ON identity_documents (employee_id, id);
+-- the primary document is the one served as the employee passport
+CREATE UNIQUE INDEX IF NOT EXISTS identity_documents_primary_key ON identity_documents (employee_id) WHERE is_primary;
+-- archived mirrors the owner's deleted_at: archived employees may share a passport with an active one,
+-- the same way employees_passport_number_key allowed it
+CREATE UNIQUE INDEX IF NOT EXISTS identity_documents_type_number_key ON identity_documents (type, number) WHERE NOT archived;
+
+INSERT INTO identity_documents (employee_id, type, number, is_primary, archived, created_at, updated_at)
+SELECT id, passport_type, passport_number, true, deleted_at IS NOT NULL, created_at, updated_at
+FROM employees;
+
+ALTER TABLE employees DROP COLUMN IF EXISTS passport_type;
+ALTER TABLE employees DROP COLUMN IF EXISTS passport_number;
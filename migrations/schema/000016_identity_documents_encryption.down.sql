@@ -0,0 +1,18 @@
+-- sealed numbers can only be opened by the application, they would be lost here
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM identity_documents WHERE number IS NULL) THEN
+            RAISE EXCEPTION 'identity document numbers are encrypted and can not be migrated down';
+        END IF;
+    END
+$$;
+
+DROP INDEX IF EXISTS identity_documents_number_key_idx;
+DROP INDEX IF EXISTS identity_documents_type_number_hash_key;
+ALTER TABLE identity_documents
+    DROP CONSTRAINT IF EXISTS identity_documents_number_check,
+    DROP COLUMN IF EXISTS number_ciphertext,
+    DROP COLUMN IF EXISTS number_key_id,
+    DROP COLUMN IF EXISTS number_hash,
+    ALTER COLUMN number SET NOT NULL;
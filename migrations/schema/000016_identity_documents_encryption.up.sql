@@ -0,0 +1,29 @@
+-- numbers are sealed by the application: number_ciphertext holds the wrapped data key and the sealed number,
+-- number_key_id the key that wraps the data key and number_hash the blind index uniqueness is checked on.
+-- The plaintext column is emptied by the encrypt-documents command, the server runs it on start.
+ALTER TABLE identity_documents
+    ADD COLUMN IF NOT EXISTS number_hash BYTEA,
+    ADD COLUMN IF NOT EXISTS number_key_id TEXT,
+    ADD COLUMN IF NOT EXISTS number_ciphertext BYTEA,
+    ALTER COLUMN number DROP NOT NULL;
+
+ALTER TABLE identity_documents
+    ADD CONSTRAINT identity_documents_number_check CHECK (
+        number IS NOT NULL OR (number_hash IS NOT NULL AND number_key_id IS NOT NULL AND number_ciphertext IS NOT NULL));
+
+CREATE UNIQUE INDEX IF NOT EXISTS identity_documents_type_number_hash_key ON identity_documents (type, number_hash) WHERE NOT archived;
+CREATE INDEX IF NOT EXISTS identity_documents_number_key_idx ON identity_documents (number_key_id);
+
+-- the audit log keeps only masked numbers, the same way pii.Mask masks them
+UPDATE audit_log
+SET before = CASE
+                 WHEN before ->> 'passport_number' <> ''
+                     THEN jsonb_set(before, '{passport_number}', to_jsonb('****' || right(before ->> 'passport_number', 4)))
+                 WHEN before ->> 'number' <> '' THEN jsonb_set(before, '{number}', to_jsonb('****' || right(before ->> 'number', 4)))
+                 ELSE before END,
+    after  = CASE
+                 WHEN after ->> 'passport_number' <> ''
+                     THEN jsonb_set(after, '{passport_number}', to_jsonb('****' || right(after ->> 'passport_number', 4)))
+                 WHEN after ->> 'number' <> '' THEN jsonb_set(after, '{number}', to_jsonb('****' || right(after ->> 'number', 4)))
+                 ELSE after END
+WHERE entity_type IN ('employee', 'identity_document');
@@ -0,0 +1,10 @@
+-- checkpoint of the rotate-keys command: documents up to last_document_id are sealed with key_id,
+-- an interrupted rotation continues after it
+CREATE TABLE IF NOT EXISTS pii_key_rotations (
+    key_id TEXT PRIMARY KEY,
+    last_document_id INTEGER NOT NULL DEFAULT 0,
+    rotated BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ
+);
@@ -0,0 +1,10 @@
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS employees_company_id_fkey,
+    DROP CONSTRAINT IF EXISTS employees_department_id_fkey,
+    DROP CONSTRAINT IF EXISTS employees_department_company_fkey;
+
+ALTER TABLE employees
+    ADD CONSTRAINT employees_company_id_fkey FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    ADD CONSTRAINT employees_department_id_fkey FOREIGN KEY (department_id) REFERENCES departments (id) ON DELETE CASCADE,
+    ADD CONSTRAINT employees_department_company_fkey
+        FOREIGN KEY (department_id, company_id) REFERENCES departments (id, company_id);
@@ -0,0 +1,12 @@
+-- employees are only removed through archiving and purging, which audit them,
+-- deleting their company or department is rejected instead of cascading
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS employees_company_id_fkey,
+    DROP CONSTRAINT IF EXISTS employees_department_id_fkey,
+    DROP CONSTRAINT IF EXISTS employees_department_company_fkey;
+
+ALTER TABLE employees
+    ADD CONSTRAINT employees_company_id_fkey FOREIGN KEY (company_id) REFERENCES companies (id),
+    ADD CONSTRAINT employees_department_id_fkey FOREIGN KEY (department_id) REFERENCES departments (id),
+    ADD CONSTRAINT employees_department_company_fkey
+        FOREIGN KEY (department_id, company_id) REFERENCES departments (id, company_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS employees_company_id_idx;
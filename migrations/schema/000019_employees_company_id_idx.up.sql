@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS employees_company_id_idx ON employees (company_id, id);
@@ -0,0 +1,48 @@
+-- name: ApplyAssignment :exec
+UPDATE department_assignments
+SET status = 'applied', from_department_id = $2, applied_at = now()
+WHERE id = $1;
+
+-- name: CancelAssignment :exec
+UPDATE department_assignments
+SET status = 'cancelled'
+WHERE id = $1 AND status = 'scheduled';
+
+-- name: CreateAssignment :one
+INSERT INTO department_assignments (employee_id, from_department_id, department_id, effective_date, status, reason, actor)
+VALUES ($1, $2, $3, $4, 'scheduled', $5, $6)
+RETURNING id, created_at;
+
+-- name: CreateAppliedAssignments :exec
+INSERT INTO department_assignments (employee_id, department_id, effective_date, status, actor, applied_at)
+SELECT e.id, e.department_id, current_date, 'applied', sqlc.arg(actor)::text, now()
+FROM employees e
+WHERE e.id = ANY(sqlc.arg(employee_ids)::int[]);
+
+-- name: GetAssignmentForUpdate :one
+SELECT id, employee_id, from_department_id, department_id, effective_date, status, reason, actor, created_at, applied_at
+FROM department_assignments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListDueAssignments :many
+SELECT id, employee_id, from_department_id, department_id, effective_date, status, reason, actor, created_at, applied_at
+FROM department_assignments
+WHERE status = 'scheduled'
+  AND effective_date <= $1
+ORDER BY effective_date, id
+LIMIT $2
+FOR UPDATE SKIP LOCKED;
+
+-- name: ListEmployeeAssignments :many
+SELECT a.id, a.employee_id, a.from_department_id, fd.name AS from_department_name, a.department_id, d.name AS department_name,
+       a.effective_date, a.status, a.reason, a.actor, a.created_at, a.applied_at
+FROM department_assignments a
+LEFT JOIN departments fd ON fd.id = a.from_department_id
+JOIN departments d ON d.id = a.department_id
+WHERE a.employee_id = $1
+ORDER BY a.effective_date, a.id;
+
+-- name: RecordAssignment :exec
+INSERT INTO department_assignments (employee_id, from_department_id, department_id, effective_date, status, reason, actor, applied_at)
+VALUES ($1, $2, $3, current_date, 'applied', $4, $5, now());
@@ -0,0 +1,15 @@
+-- name: CreateAuditRecord :exec
+INSERT INTO audit_log (entity_type, entity_id, operation, actor, before, after, changed_fields)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: CopyAuditRecords :copyfrom
+INSERT INTO audit_log (entity_type, entity_id, operation, actor, before, after, changed_fields)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListAuditRecords :many
+SELECT id, operation, actor, before, after, changed_fields, created_at
+FROM audit_log
+WHERE entity_type = $1
+  AND entity_id = $2
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
@@ -0,0 +1,108 @@
+-- name: ListIdentityDocuments :many
+SELECT id, employee_id, type, number, issuer, issue_date, expiry_date, country, is_primary, archived, created_at, updated_at,
+       number_hash, number_key_id, number_ciphertext
+FROM identity_documents
+WHERE employee_id = $1
+ORDER BY is_primary DESC, id;
+
+-- name: GetIdentityDocument :one
+SELECT id, employee_id, type, number, issuer, issue_date, expiry_date, country, is_primary, archived, created_at, updated_at,
+       number_hash, number_key_id, number_ciphertext
+FROM identity_documents
+WHERE id = $1
+  AND employee_id = $2;
+
+-- name: GetIdentityDocumentForUpdate :one
+SELECT id, employee_id, type, number, issuer, issue_date, expiry_date, country, is_primary, archived, created_at, updated_at,
+       number_hash, number_key_id, number_ciphertext
+FROM identity_documents
+WHERE id = $1
+  AND employee_id = $2
+FOR UPDATE;
+
+-- name: CreateIdentityDocument :one
+INSERT INTO identity_documents (employee_id, type, number_hash, number_key_id, number_ciphertext, issuer, issue_date,
+                                expiry_date, country, is_primary)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id;
+
+-- name: CopyIdentityDocuments :copyfrom
+INSERT INTO identity_documents (employee_id, type, number_hash, number_key_id, number_ciphertext, is_primary)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpsertPrimaryIdentityDocument :exec
+INSERT INTO identity_documents (employee_id, type, number_hash, number_key_id, number_ciphertext, is_primary)
+VALUES ($1, $2, $3, $4, $5, true)
+ON CONFLICT (employee_id) WHERE is_primary DO UPDATE
+    SET type=excluded.type,
+        number=NULL,
+        number_hash=excluded.number_hash,
+        number_key_id=excluded.number_key_id,
+        number_ciphertext=excluded.number_ciphertext,
+        updated_at=now();
+
+-- name: UpdateIdentityDocument :exec
+UPDATE identity_documents
+SET type=$2,
+    number=NULL,
+    number_hash=$3,
+    number_key_id=$4,
+    number_ciphertext=$5,
+    issuer=$6,
+    issue_date=$7,
+    expiry_date=$8,
+    country=$9,
+    is_primary=$10,
+    updated_at=now()
+WHERE id = $1;
+
+-- name: ClearPrimaryIdentityDocument :exec
+UPDATE identity_documents
+SET is_primary=false,
+    updated_at=now()
+WHERE employee_id = $1
+  AND is_primary;
+
+-- name: DeleteIdentityDocument :execrows
+DELETE
+FROM identity_documents
+WHERE id = $1;
+
+-- name: SetIdentityDocumentsArchived :exec
+UPDATE identity_documents
+SET archived=$2
+WHERE employee_id = $1;
+
+-- name: ListPlaintextIdentityDocuments :many
+SELECT id, number::text AS number
+FROM identity_documents
+WHERE number IS NOT NULL
+ORDER BY id
+LIMIT $1 FOR UPDATE SKIP LOCKED;
+
+-- name: SealIdentityDocumentNumber :exec
+UPDATE identity_documents
+SET number=NULL,
+    number_hash=$2,
+    number_key_id=$3,
+    number_ciphertext=$4
+WHERE id = $1;
+
+-- name: CountIdentityDocumentsToRotate :one
+SELECT count(*)
+FROM identity_documents
+WHERE id > $1
+  AND number_key_id <> $2;
+
+-- name: ListIdentityDocumentsToRotate :many
+SELECT id, number_key_id, number_ciphertext
+FROM identity_documents
+WHERE id > $1
+  AND number_key_id <> $2
+ORDER BY id
+LIMIT $3 FOR UPDATE;
+
+-- name: RotateIdentityDocumentNumber :exec
+UPDATE identity_documents
+SET number_key_id=$2,
+    number_ciphertext=$3
+WHERE id = $1;
@@ -2,81 +2,433 @@
 INSERT INTO companies (name)
 VALUES ($1) RETURNING id;
 
+-- name: GetCompanies :many
+SELECT id, name
+FROM companies
+ORDER BY id asc
+LIMIT $1 OFFSET $2;
+
+-- name: GetCompanyByID :one
+SELECT id, name
+FROM companies
+WHERE id = $1;
+
+-- name: GetCompanyForUpdate :one
+SELECT id, name
+FROM companies
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateCompany :execrows
+UPDATE companies
+SET name=$2
+WHERE id = $1;
+
+-- name: DeleteCompany :execrows
+DELETE
+FROM companies
+WHERE id = $1;
+
 -- name: CreateDepartment :one
-INSERT INTO departments (name, phone, company_id)
-VALUES ($1, $2, $3) ON CONFLICT (name, company_id) DO NOTHING
+INSERT INTO departments (name, phone, company_id, parent_department_id)
+VALUES ($1, $2, $3, $4) ON CONFLICT (name, company_id) DO NOTHING
 RETURNING id;
 
 -- name: CreateEmployee :one
-INSERT INTO employees (name, surname, phone, company_id, department_id, passport_type, passport_number)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;
+INSERT INTO employees (name, surname, phone, company_id, department_id, manager_id)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;
+
+-- name: CopyEmployees :copyfrom
+INSERT INTO employees (name, surname, phone, company_id, department_id)
+VALUES ($1, $2, $3, $4, $5);
 
--- name: GetListCompanyEmployee :many
+-- name: FindEmployeesByIdentifiers :many
+SELECT e.id,
+       e.phone,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext
+FROM employees e
+         LEFT JOIN identity_documents p ON p.employee_id = e.id
+    AND (p.number_hash = ANY (@passport_hashes::bytea[]) OR p.number = ANY (@passport_numbers::text[]))
+WHERE (e.deleted_at IS NULL AND e.phone = ANY (@phones::text[]))
+   OR p.id IS NOT NULL;
+
+-- one query per sort order, so that the pages are read from the (company_id, <sort>, id) indexes.
+-- The first page starts after the lowest key.
+-- name: ListEmployeesByCreatedAt :many
 SELECT e.id,
        e.name,
        e.surname,
        e.phone,
        e.company_id,
-       e.passport_type,
-       e.passport_number,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
        d.name,
        d.phone
 FROM employees e
          JOIN departments d ON e.department_id = d.id
-WHERE e.company_id = $1
-ORDER BY e.id asc;
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = @company_id
+  AND e.deleted_at IS NULL
+  AND (sqlc.narg(department_id)::int IS NULL OR e.department_id = sqlc.narg(department_id))
+  AND (sqlc.narg(surname_prefix)::text IS NULL OR e.surname LIKE sqlc.narg(surname_prefix) || '%')
+  AND (sqlc.narg(passport_type)::text IS NULL OR p.type = sqlc.narg(passport_type))
+  AND (e.created_at, e.id) > (@after_created_at::timestamptz, @after_id::int)
+ORDER BY e.created_at, e.id
+LIMIT @page_limit;
 
--- name: GetListCompanyDepartmentEmployee :many
+-- name: ListEmployeesByID :many
 SELECT e.id,
        e.name,
        e.surname,
        e.phone,
        e.company_id,
-       e.passport_type,
-       e.passport_number,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
        d.name,
        d.phone
 FROM employees e
          JOIN departments d ON e.department_id = d.id
-WHERE e.department_id = $1
-ORDER BY e.id asc;
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = @company_id
+  AND e.deleted_at IS NULL
+  AND (sqlc.narg(department_id)::int IS NULL OR e.department_id = sqlc.narg(department_id))
+  AND (sqlc.narg(surname_prefix)::text IS NULL OR e.surname LIKE sqlc.narg(surname_prefix) || '%')
+  AND (sqlc.narg(passport_type)::text IS NULL OR p.type = sqlc.narg(passport_type))
+  AND e.id > @after_id::int
+ORDER BY e.id
+LIMIT @page_limit;
 
--- name: DeleteEmployee :exec
-DELETE
+-- name: ListEmployeesByName :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = @company_id
+  AND e.deleted_at IS NULL
+  AND (sqlc.narg(department_id)::int IS NULL OR e.department_id = sqlc.narg(department_id))
+  AND (sqlc.narg(surname_prefix)::text IS NULL OR e.surname LIKE sqlc.narg(surname_prefix) || '%')
+  AND (sqlc.narg(passport_type)::text IS NULL OR p.type = sqlc.narg(passport_type))
+  AND (e.name, e.id) > (@after_name::text, @after_id::int)
+ORDER BY e.name, e.id
+LIMIT @page_limit;
+
+-- name: ListEmployeesBySurname :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.created_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.company_id = @company_id
+  AND e.deleted_at IS NULL
+  AND (sqlc.narg(department_id)::int IS NULL OR e.department_id = sqlc.narg(department_id))
+  AND (sqlc.narg(surname_prefix)::text IS NULL OR e.surname LIKE sqlc.narg(surname_prefix) || '%')
+  AND (sqlc.narg(passport_type)::text IS NULL OR p.type = sqlc.narg(passport_type))
+  AND (e.surname, e.id) > (@after_surname::text, @after_id::int)
+ORDER BY e.surname, e.id
+LIMIT @page_limit;
+
+-- name: CountCompanyEmployees :one
+SELECT count(*)
+FROM employees
+WHERE company_id = $1;
+
+-- name: CountDepartmentEmployees :one
+SELECT count(*)
 FROM employees
+WHERE department_id = $1;
+
+-- name: CountEmployees :one
+SELECT count(*)
+FROM employees e
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.deleted_at IS NULL
+  AND (sqlc.narg(company_id)::int IS NULL OR e.company_id = sqlc.narg(company_id))
+  AND (sqlc.narg(department_id)::int IS NULL OR e.department_id = sqlc.narg(department_id))
+  AND (sqlc.narg(surname_prefix)::text IS NULL OR e.surname LIKE sqlc.narg(surname_prefix) || '%')
+  AND (sqlc.narg(passport_type)::text IS NULL OR p.type = sqlc.narg(passport_type));
+
+-- name: SearchEmployees :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       d.id,
+       d.name,
+       d.phone,
+       (ts_rank(to_tsvector('simple', e.name || ' ' || e.surname || ' ' || e.phone),
+                plainto_tsquery('simple', @query::text)) +
+        greatest(similarity(e.name, @query), similarity(e.surname, @query), similarity(e.phone, @query)))::real AS rank
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.deleted_at IS NULL
+  AND (sqlc.narg(company_id)::int IS NULL OR e.company_id = sqlc.narg(company_id))
+  AND (to_tsvector('simple', e.name || ' ' || e.surname || ' ' || e.phone) @@ plainto_tsquery('simple', @query)
+    OR e.name ILIKE '%' || @pattern::text || '%'
+    OR e.surname ILIKE '%' || @pattern || '%'
+    OR e.phone LIKE '%' || @pattern || '%'
+    OR e.name % @query
+    OR e.surname % @query)
+ORDER BY rank DESC, e.id
+LIMIT @page_limit;
+
+-- name: ArchiveEmployee :execrows
+UPDATE employees
+SET deleted_at=now(),
+    updated_at=now(),
+    version=version + 1
+WHERE id = $1
+  AND deleted_at IS NULL;
+
+-- name: BumpEmployeeVersion :exec
+UPDATE employees
+SET updated_at=now(),
+    version=version + 1
 WHERE id = $1;
 
--- name: UpdateEmployee :exec
+-- name: RestoreEmployee :one
+WITH restored AS (
+    UPDATE employees
+        SET deleted_at = NULL,
+            updated_at = now(),
+            version = version + 1
+        WHERE id = $1
+            AND deleted_at IS NOT NULL
+        RETURNING id, name, surname, phone, company_id, department_id, manager_id)
+SELECT r.id,
+       r.name,
+       r.surname,
+       r.phone,
+       r.company_id,
+       r.department_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       r.manager_id
+FROM restored r
+         LEFT JOIN identity_documents p ON p.employee_id = r.id AND p.is_primary;
+
+-- name: PurgeEmployees :execrows
+DELETE
+FROM employees
+WHERE deleted_at < $1;
+
+-- name: ListArchivedEmployees :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.deleted_at,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.deleted_at IS NOT NULL
+  AND (sqlc.narg(company_id)::int IS NULL OR e.company_id = sqlc.narg(company_id))
+ORDER BY e.deleted_at DESC, e.id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: UpdateEmployee :one
 UPDATE employees
 SET name=$2,
     surname=$3,
     phone=$4,
     company_id=$5,
     department_id=$6,
-    passport_type=$7,
-    passport_number=$8,
-    updated_at=now()
-WHERE id = $1;
+    manager_id=$7,
+    updated_at=now(),
+    version=version + 1
+WHERE id = $1
+RETURNING version;
 
 -- name: GetEmployeeByID :one
-SELECT id,
-       name,
-       surname,
-       phone,
-       company_id,
-       passport_type,
-       passport_number,
-       department_id
-FROM employees
-WHERE id = $1;
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.department_id,
+       e.version,
+       e.manager_id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.id = $1
+  AND e.deleted_at IS NULL;
 
 
+-- name: GetEmployeeForUpdate :one
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.department_id,
+       e.version,
+       e.manager_id
+FROM employees e
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.id = $1
+  AND e.deleted_at IS NULL
+FOR UPDATE OF e;
+
 -- name: GetDepartmentByID :one
-SELECT name, phone
+SELECT id, name, phone, company_id, parent_department_id
+FROM departments
+WHERE id = $1;
+
+-- name: GetDepartmentForUpdate :one
+SELECT id, name, phone, company_id, parent_department_id
+FROM departments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetCompanyDepartments :many
+SELECT id, name, phone, company_id, parent_department_id
+FROM departments
+WHERE company_id = $1
+ORDER BY id asc;
+
+-- name: UpdateDepartment :execrows
+UPDATE departments
+SET name=$2,
+    phone=$3,
+    parent_department_id=$4
+WHERE id = $1;
+
+-- name: DeleteDepartment :execrows
+DELETE
 FROM departments
 WHERE id = $1;
 
 -- name: GetDepartmentID :one
 SELECT department_id
 FROM employees
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetEmployeeScope :one
+SELECT company_id, department_id
+FROM employees
+WHERE id = $1;
+
+-- name: GetDepartmentSubtree :many
+WITH RECURSIVE subtree AS (SELECT id, parent_department_id, 0 AS depth, ARRAY [id] AS path
+                           FROM departments
+                           WHERE departments.id = $1
+                           UNION ALL
+                           SELECT d.id, d.parent_department_id, s.depth + 1, s.path || d.id
+                           FROM departments d
+                                    JOIN subtree s ON d.parent_department_id = s.id
+                           WHERE NOT d.id = ANY (s.path))
+SELECT d.id, d.name, d.phone, d.company_id, d.parent_department_id, s.depth::int AS depth
+FROM subtree s
+         JOIN departments d ON d.id = s.id
+ORDER BY s.path;
+
+-- name: GetReportingChain :many
+WITH RECURSIVE chain AS (SELECT id, manager_id, 0 AS depth, ARRAY [id] AS path
+                         FROM employees
+                         WHERE employees.id = $1
+                           AND deleted_at IS NULL
+                         UNION ALL
+                         SELECT e.id, e.manager_id, c.depth + 1, c.path || e.id
+                         FROM employees e
+                                  JOIN chain c ON e.id = c.manager_id
+                         WHERE e.deleted_at IS NULL
+                           AND NOT e.id = ANY (c.path))
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.manager_id,
+       d.id,
+       d.name,
+       d.phone,
+       c.depth::int AS depth
+FROM chain c
+         JOIN employees e ON e.id = c.id
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+ORDER BY c.depth;
+
+-- name: ListDirectReports :many
+SELECT e.id,
+       e.name,
+       e.surname,
+       e.phone,
+       e.company_id,
+       COALESCE(p.type, '')::text   AS passport_type,
+       COALESCE(p.number, '')::text AS passport_number,
+       p.number_key_id              AS passport_key_id,
+       p.number_ciphertext          AS passport_ciphertext,
+       e.manager_id,
+       d.id,
+       d.name,
+       d.phone
+FROM employees e
+         JOIN departments d ON e.department_id = d.id
+         LEFT JOIN identity_documents p ON p.employee_id = e.id AND p.is_primary
+WHERE e.manager_id = @manager_id::int
+  AND e.deleted_at IS NULL
+ORDER BY e.id;
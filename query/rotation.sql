@@ -0,0 +1,24 @@
+-- name: StartKeyRotation :one
+-- an unfinished rotation to the key continues from its checkpoint, a finished one starts over
+INSERT INTO pii_key_rotations (key_id)
+VALUES ($1)
+ON CONFLICT (key_id) DO UPDATE
+    SET last_document_id = CASE WHEN pii_key_rotations.finished_at IS NULL THEN pii_key_rotations.last_document_id ELSE 0 END,
+        rotated          = CASE WHEN pii_key_rotations.finished_at IS NULL THEN pii_key_rotations.rotated ELSE 0 END,
+        started_at       = CASE WHEN pii_key_rotations.finished_at IS NULL THEN pii_key_rotations.started_at ELSE now() END,
+        finished_at      = NULL,
+        updated_at       = now()
+RETURNING key_id, last_document_id, rotated, started_at, updated_at, finished_at;
+
+-- name: UpdateKeyRotation :exec
+UPDATE pii_key_rotations
+SET last_document_id=$2,
+    rotated=rotated + $3,
+    updated_at=now()
+WHERE key_id = $1;
+
+-- name: FinishKeyRotation :exec
+UPDATE pii_key_rotations
+SET finished_at=now(),
+    updated_at=now()
+WHERE key_id = $1;